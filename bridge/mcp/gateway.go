@@ -0,0 +1,578 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/viant/jsonrpc"
+	"github.com/viant/jsonrpc/transport"
+	sse "github.com/viant/jsonrpc/transport/client/http/sse"
+	streamable "github.com/viant/jsonrpc/transport/client/http/streamable"
+	"github.com/viant/jsonrpc/transport/client/stdio"
+	stdiosrv "github.com/viant/jsonrpc/transport/server/stdio"
+	mcpclient "github.com/viant/mcp"
+	protoClient "github.com/viant/mcp-protocol/client"
+	protologger "github.com/viant/mcp-protocol/logger"
+	"github.com/viant/mcp-protocol/schema"
+	protoserver "github.com/viant/mcp-protocol/server"
+	"github.com/viant/mcp/client"
+	mcpserver "github.com/viant/mcp/server"
+)
+
+// maxUpstreamPages guards against upstreams returning cursors indefinitely.
+const maxUpstreamPages = 100
+
+// Upstream describes an MCP server aggregated by the gateway.
+type Upstream struct {
+	Name string `yaml:"name" json:"name"`
+	// Prefix is prepended to tool and prompt names; nil defaults to Name followed by the gateway separator,
+	// an empty string exposes names unchanged.
+	Prefix    *string                   `yaml:"prefix,omitempty" json:"prefix,omitempty"`
+	Transport mcpclient.ClientTransport `yaml:"transport" json:"transport"`
+	// Dial overrides transport construction, e.g. to connect an in-process server.
+	Dial func(ctx context.Context, handler transport.Handler) (transport.Transport, error) `yaml:"-" json:"-"`
+}
+
+// GatewayOptions configures a gateway.
+type GatewayOptions struct {
+	Upstreams []*Upstream `yaml:"upstreams" json:"upstreams"`
+	// Separator joins an upstream name and a tool or prompt name when Prefix is not set (default "_").
+	Separator string `yaml:"separator,omitempty" json:"separator,omitempty"`
+}
+
+// Gateway exposes several upstream MCP servers as a single server.
+type Gateway struct {
+	upstreams []*upstream
+}
+
+// upstream describes an upstream server; each downstream session dials its own connection.
+type upstream struct {
+	name   string
+	prefix string
+	config *Upstream
+	mu     sync.Mutex
+	probed *probedConnection
+}
+
+// probedConnection is the connection dialed by NewGateway, adopted by the first downstream session.
+type probedConnection struct {
+	transport transport.Transport
+	handler   *dynamicHandler
+}
+
+// dial opens a new upstream connection delivering upstream back-calls and notifications to handler.
+func (u *upstream) dial(ctx context.Context, handler transport.Handler) (transport.Transport, error) {
+	aTransport, err := dialUpstream(ctx, u.config, handler)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+	return aTransport, nil
+}
+
+// connect returns a connection owned by a single downstream session, adopting the probed connection once.
+func (u *upstream) connect(ctx context.Context, handler transport.Handler) (transport.Transport, error) {
+	u.mu.Lock()
+	probed := u.probed
+	u.probed = nil
+	u.mu.Unlock()
+	if probed != nil {
+		probed.handler.SetInner(handler)
+		return probed.transport, nil
+	}
+	return u.dial(ctx, handler)
+}
+
+// NewGateway checks that at least one upstream is reachable. Every downstream session dials its own upstream
+// connections, so upstream back-calls reach only the session that caused them; an upstream that fails to
+// connect is reported on use and dialed again for later downstream sessions.
+func NewGateway(ctx context.Context, options *GatewayOptions) (*Gateway, error) {
+	if len(options.Upstreams) == 0 {
+		return nil, errors.New("gateway: no upstreams configured")
+	}
+	separator := options.Separator
+	if separator == "" {
+		separator = "_"
+	}
+	ret := &Gateway{}
+	var errs []error
+	for _, cfg := range options.Upstreams {
+		if cfg.Name == "" {
+			return nil, errors.New("gateway: upstream name is required")
+		}
+		prefix := cfg.Name + separator
+		if cfg.Prefix != nil {
+			prefix = *cfg.Prefix
+		}
+		item := &upstream{name: cfg.Name, prefix: prefix, config: cfg}
+		probe := &dynamicHandler{}
+		if aTransport, err := item.dial(ctx, probe); err != nil {
+			errs = append(errs, fmt.Errorf("%v: %w", cfg.Name, err))
+		} else {
+			item.probed = &probedConnection{transport: aTransport, handler: probe}
+		}
+		ret.upstreams = append(ret.upstreams, item)
+	}
+	if len(errs) == len(ret.upstreams) {
+		return nil, fmt.Errorf("gateway: no upstream available: %w", errors.Join(errs...))
+	}
+	return ret, nil
+}
+
+func dialUpstream(ctx context.Context, cfg *Upstream, handler transport.Handler) (transport.Transport, error) {
+	if cfg.Dial != nil {
+		return cfg.Dial(ctx, handler)
+	}
+	switch cfg.Transport.Type {
+	case "stdio":
+		if cfg.Transport.Command == "" {
+			return nil, fmt.Errorf("command is required for stdio transport")
+		}
+		return stdio.New(cfg.Transport.Command, stdio.WithHandler(handler), stdio.WithArguments(cfg.Transport.Arguments...))
+	case "sse":
+		return sse.New(ctx, cfg.Transport.URL, sse.WithHandler(handler))
+	case "streamable":
+		return streamable.New(ctx, cfg.Transport.URL, streamable.WithHandler(handler))
+	}
+	return nil, fmt.Errorf("unsupported transport type: %q", cfg.Transport.Type)
+}
+
+// NewHandler returns a server handler factory aggregating all upstreams.
+func (g *Gateway) NewHandler() protoserver.NewHandler {
+	return func(ctx context.Context, notifier transport.Notifier, logger protologger.Logger, clientOps protoClient.Operations) (protoserver.Handler, error) {
+		// upstream back-calls and notifications are forwarded to this downstream session only
+		handler := &opsHandler{Operations: clientOps}
+		remoteHandler := client.NewHandler(handler)
+		ret := &gatewayHandler{logger: logger}
+		for _, item := range g.upstreams {
+			item := item
+			// ctx may be scoped to the request opening the session; the connection lives with the session
+			aTransport, err := item.connect(context.WithoutCancel(ctx), remoteHandler)
+			session := &gatewayUpstream{upstream: item, err: err}
+			if err == nil {
+				session.impl = &clientImplementer{
+					downstream: aTransport,
+					reconnect: func(ctx context.Context) (transport.Transport, error) {
+						return item.dial(ctx, remoteHandler)
+					},
+					clientOps:     clientOps,
+					clientHandler: handler,
+				}
+			}
+			ret.upstreams = append(ret.upstreams, session)
+		}
+		return ret, nil
+	}
+}
+
+// Server creates an MCP server backed by the gateway.
+func (g *Gateway) Server(options ...mcpserver.Option) (*mcpserver.Server, error) {
+	return mcpserver.New(append([]mcpserver.Option{mcpserver.WithNewHandler(g.NewHandler())}, options...)...)
+}
+
+// HTTP starts an HTTP server exposing the gateway.
+func (g *Gateway) HTTP(ctx context.Context, addr string) (*http.Server, error) {
+	srv, err := g.Server()
+	if err != nil {
+		return nil, err
+	}
+	return srv.HTTP(ctx, addr), nil
+}
+
+// Stdio starts a JSON-RPC server over standard input/output exposing the gateway.
+func (g *Gateway) Stdio(ctx context.Context) (*stdiosrv.Server, error) {
+	srv, err := g.Server()
+	if err != nil {
+		return nil, err
+	}
+	return stdiosrv.New(ctx, srv.NewHandler), nil
+}
+
+// gatewayUpstream is an upstream bound to a downstream session.
+type gatewayUpstream struct {
+	*upstream
+	impl         *clientImplementer
+	capabilities schema.ServerCapabilities
+	err          error
+}
+
+type gatewayRoute struct {
+	upstream *gatewayUpstream
+	name     string
+}
+
+// gatewayHandler merges upstream lists and routes calls for a single downstream session.
+type gatewayHandler struct {
+	logger    protologger.Logger
+	upstreams []*gatewayUpstream
+	mu        sync.RWMutex
+	tools     map[string]*gatewayRoute
+	prompts   map[string]*gatewayRoute
+	resources map[string]*gatewayUpstream
+}
+
+// Initialize initializes every available upstream and merges their capabilities.
+func (h *gatewayHandler) Initialize(ctx context.Context, init *schema.InitializeRequestParams, result *schema.InitializeResult) {
+	capabilities := &result.Capabilities
+	for _, item := range h.upstreams {
+		if item.err != nil {
+			h.warn(ctx, item, "initialize", item.err)
+			continue
+		}
+		upstreamResult := &schema.InitializeResult{}
+		item.impl.Initialize(ctx, init, upstreamResult)
+		if item.impl.initErr != nil {
+			item.err = fmt.Errorf("failed to initialize: %w", item.impl.initErr)
+			h.warn(ctx, item, "initialize", item.err)
+			continue
+		}
+		item.capabilities = upstreamResult.Capabilities
+		upstreamCapabilities := &upstreamResult.Capabilities
+		if upstreamCapabilities.Tools != nil && capabilities.Tools == nil {
+			capabilities.Tools = &schema.ServerCapabilitiesTools{}
+		}
+		if upstreamCapabilities.Prompts != nil && capabilities.Prompts == nil {
+			capabilities.Prompts = &schema.ServerCapabilitiesPrompts{}
+		}
+		if upstreamCapabilities.Resources != nil {
+			if capabilities.Resources == nil {
+				capabilities.Resources = &schema.ServerCapabilitiesResources{}
+			}
+			if subscribe := upstreamCapabilities.Resources.Subscribe; subscribe != nil && *subscribe {
+				capabilities.Resources.Subscribe = subscribe
+			}
+		}
+		if upstreamCapabilities.Logging != nil && capabilities.Logging == nil {
+			capabilities.Logging = map[string]interface{}{}
+		}
+		if upstreamCapabilities.Completions != nil && capabilities.Completions == nil {
+			capabilities.Completions = map[string]interface{}{}
+		}
+	}
+}
+
+// ListTools lists tools of all upstreams with their name prefixes.
+func (h *gatewayHandler) ListTools(ctx context.Context, request *jsonrpc.TypedRequest[*schema.ListToolsRequest]) (*schema.ListToolsResult, *jsonrpc.Error) {
+	result := &schema.ListToolsResult{}
+	routes := map[string]*gatewayRoute{}
+	for _, item := range h.available(func(c *schema.ServerCapabilities) bool { return c.Tools != nil }) {
+		var cursor *string
+		for page := 0; page < maxUpstreamPages; page++ {
+			res, err := item.impl.endpoint.ListTools(ctx, cursor)
+			if err != nil {
+				h.warn(ctx, item, schema.MethodToolsList, err)
+				break
+			}
+			for _, tool := range res.Tools {
+				name := item.prefix + tool.Name
+				if _, ok := routes[name]; ok {
+					h.warn(ctx, item, schema.MethodToolsList, fmt.Errorf("duplicate tool %v", name))
+					continue
+				}
+				routes[name] = &gatewayRoute{upstream: item, name: tool.Name}
+				tool.Name = name
+				result.Tools = append(result.Tools, tool)
+			}
+			if cursor = res.NextCursor; cursor == nil || *cursor == "" {
+				break
+			}
+		}
+	}
+	h.mu.Lock()
+	h.tools = routes
+	h.mu.Unlock()
+	return result, nil
+}
+
+// CallTool routes the call to the upstream owning the tool.
+func (h *gatewayHandler) CallTool(ctx context.Context, request *jsonrpc.TypedRequest[*schema.CallToolRequest]) (*schema.CallToolResult, *jsonrpc.Error) {
+	route, rErr := h.route(ctx, request.Request.Params.Name, func() map[string]*gatewayRoute { return h.tools }, func() {
+		_, _ = h.ListTools(ctx, nil)
+	})
+	if rErr != nil {
+		return nil, rErr
+	}
+	params := request.Request.Params
+	params.Name = route.name
+	options, done := h.cancellable(ctx, route.upstream)
+	defer done()
+	res, err := route.upstream.impl.endpoint.CallTool(ctx, &params, options...)
+	if err != nil {
+		return nil, jsonrpc.NewInternalError(err.Error(), nil)
+	}
+	return res, nil
+}
+
+// ListPrompts lists prompts of all upstreams with their name prefixes.
+func (h *gatewayHandler) ListPrompts(ctx context.Context, request *jsonrpc.TypedRequest[*schema.ListPromptsRequest]) (*schema.ListPromptsResult, *jsonrpc.Error) {
+	result := &schema.ListPromptsResult{}
+	routes := map[string]*gatewayRoute{}
+	for _, item := range h.available(func(c *schema.ServerCapabilities) bool { return c.Prompts != nil }) {
+		var cursor *string
+		for page := 0; page < maxUpstreamPages; page++ {
+			res, err := item.impl.endpoint.ListPrompts(ctx, cursor)
+			if err != nil {
+				h.warn(ctx, item, schema.MethodPromptsList, err)
+				break
+			}
+			for _, prompt := range res.Prompts {
+				name := item.prefix + prompt.Name
+				if _, ok := routes[name]; ok {
+					h.warn(ctx, item, schema.MethodPromptsList, fmt.Errorf("duplicate prompt %v", name))
+					continue
+				}
+				routes[name] = &gatewayRoute{upstream: item, name: prompt.Name}
+				prompt.Name = name
+				result.Prompts = append(result.Prompts, prompt)
+			}
+			if cursor = res.NextCursor; cursor == nil || *cursor == "" {
+				break
+			}
+		}
+	}
+	h.mu.Lock()
+	h.prompts = routes
+	h.mu.Unlock()
+	return result, nil
+}
+
+// GetPrompt routes the request to the upstream owning the prompt.
+func (h *gatewayHandler) GetPrompt(ctx context.Context, request *jsonrpc.TypedRequest[*schema.GetPromptRequest]) (*schema.GetPromptResult, *jsonrpc.Error) {
+	route, rErr := h.route(ctx, request.Request.Params.Name, func() map[string]*gatewayRoute { return h.prompts }, func() {
+		_, _ = h.ListPrompts(ctx, nil)
+	})
+	if rErr != nil {
+		return nil, rErr
+	}
+	params := request.Request.Params
+	params.Name = route.name
+	options, done := h.cancellable(ctx, route.upstream)
+	defer done()
+	res, err := route.upstream.impl.endpoint.GetPrompt(ctx, &params, options...)
+	if err != nil {
+		return nil, jsonrpc.NewInternalError(err.Error(), nil)
+	}
+	return res, nil
+}
+
+// ListResources lists resources of all upstreams; URIs are exposed unchanged.
+func (h *gatewayHandler) ListResources(ctx context.Context, request *jsonrpc.TypedRequest[*schema.ListResourcesRequest]) (*schema.ListResourcesResult, *jsonrpc.Error) {
+	result := &schema.ListResourcesResult{}
+	routes := map[string]*gatewayUpstream{}
+	for _, item := range h.available(func(c *schema.ServerCapabilities) bool { return c.Resources != nil }) {
+		var cursor *string
+		for page := 0; page < maxUpstreamPages; page++ {
+			res, err := item.impl.endpoint.ListResources(ctx, cursor)
+			if err != nil {
+				h.warn(ctx, item, schema.MethodResourcesList, err)
+				break
+			}
+			for _, resource := range res.Resources {
+				if _, ok := routes[resource.Uri]; ok {
+					h.warn(ctx, item, schema.MethodResourcesList, fmt.Errorf("duplicate resource %v", resource.Uri))
+					continue
+				}
+				routes[resource.Uri] = item
+				result.Resources = append(result.Resources, resource)
+			}
+			if cursor = res.NextCursor; cursor == nil || *cursor == "" {
+				break
+			}
+		}
+	}
+	h.mu.Lock()
+	h.resources = routes
+	h.mu.Unlock()
+	return result, nil
+}
+
+// ListResourceTemplates lists resource templates of all upstreams.
+func (h *gatewayHandler) ListResourceTemplates(ctx context.Context, request *jsonrpc.TypedRequest[*schema.ListResourceTemplatesRequest]) (*schema.ListResourceTemplatesResult, *jsonrpc.Error) {
+	result := &schema.ListResourceTemplatesResult{}
+	for _, item := range h.available(func(c *schema.ServerCapabilities) bool { return c.Resources != nil }) {
+		var cursor *string
+		for page := 0; page < maxUpstreamPages; page++ {
+			res, err := item.impl.endpoint.ListResourceTemplates(ctx, cursor)
+			if err != nil {
+				h.warn(ctx, item, schema.MethodResourcesTemplatesList, err)
+				break
+			}
+			result.ResourceTemplates = append(result.ResourceTemplates, res.ResourceTemplates...)
+			if cursor = res.NextCursor; cursor == nil || *cursor == "" {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// ReadResource routes the read to the upstream listing the URI, or tries upstreams in order.
+func (h *gatewayHandler) ReadResource(ctx context.Context, request *jsonrpc.TypedRequest[*schema.ReadResourceRequest]) (*schema.ReadResourceResult, *jsonrpc.Error) {
+	var lastErr *jsonrpc.Error
+	for _, item := range h.resourceUpstreams(ctx, request.Request.Params.Uri) {
+		res, err := item.impl.ReadResource(ctx, request)
+		if err == nil {
+			return res, nil
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = jsonrpc.NewInvalidParamsError(fmt.Sprintf("resource not found: %v", request.Request.Params.Uri), nil)
+	}
+	return nil, lastErr
+}
+
+// Subscribe routes the subscription to the upstream listing the URI.
+func (h *gatewayHandler) Subscribe(ctx context.Context, request *jsonrpc.TypedRequest[*schema.SubscribeRequest]) (*schema.SubscribeResult, *jsonrpc.Error) {
+	candidates := h.resourceUpstreams(ctx, request.Request.Params.Uri)
+	if len(candidates) == 0 {
+		return nil, jsonrpc.NewInvalidParamsError(fmt.Sprintf("resource not found: %v", request.Request.Params.Uri), nil)
+	}
+	return candidates[0].impl.Subscribe(ctx, request)
+}
+
+// Unsubscribe routes the request to the upstream listing the URI.
+func (h *gatewayHandler) Unsubscribe(ctx context.Context, request *jsonrpc.TypedRequest[*schema.UnsubscribeRequest]) (*schema.UnsubscribeResult, *jsonrpc.Error) {
+	candidates := h.resourceUpstreams(ctx, request.Request.Params.Uri)
+	if len(candidates) == 0 {
+		return nil, jsonrpc.NewInvalidParamsError(fmt.Sprintf("resource not found: %v", request.Request.Params.Uri), nil)
+	}
+	return candidates[0].impl.Unsubscribe(ctx, request)
+}
+
+// Complete routes completion by prompt name or resource URI.
+func (h *gatewayHandler) Complete(ctx context.Context, request *jsonrpc.TypedRequest[*schema.CompleteRequest]) (*schema.CompleteResult, *jsonrpc.Error) {
+	ref := request.Request.Params.Ref
+	if ref.Uri != "" {
+		candidates := h.resourceUpstreams(ctx, ref.Uri)
+		if len(candidates) == 0 {
+			return nil, jsonrpc.NewInvalidParamsError(fmt.Sprintf("resource not found: %v", ref.Uri), nil)
+		}
+		return candidates[0].impl.Complete(ctx, request)
+	}
+	route, rErr := h.route(ctx, ref.Name, func() map[string]*gatewayRoute { return h.prompts }, func() {
+		_, _ = h.ListPrompts(ctx, nil)
+	})
+	if rErr != nil {
+		return nil, rErr
+	}
+	upstreamRequest := *request.Request
+	upstreamRequest.Params.Ref.Name = route.name
+	return route.upstream.impl.Complete(ctx, &jsonrpc.TypedRequest[*schema.CompleteRequest]{Id: request.Id, Method: request.Method, Request: &upstreamRequest})
+}
+
+// OnNotification forwards roots changes to all upstreams. Cancellation of a routed request cancels its context
+// and is forwarded by cancellable.
+func (h *gatewayHandler) OnNotification(ctx context.Context, notification *jsonrpc.Notification) {
+	if notification.Method != mcpserver.MethodNotificationRootsListChanged {
+		return
+	}
+	for _, item := range h.available(func(c *schema.ServerCapabilities) bool { return true }) {
+		if err := item.impl.downstream.Notify(ctx, notification); err != nil {
+			h.warn(ctx, item, notification.Method, err)
+		}
+	}
+}
+
+// cancellable assigns an upstream request id to a routed request; the returned func, called once the upstream
+// call returns, sends notifications/cancelled for that id when the downstream request was cancelled.
+func (h *gatewayHandler) cancellable(ctx context.Context, item *gatewayUpstream) ([]client.RequestOption, func()) {
+	sequencer, ok := item.impl.downstream.(transport.Sequencer)
+	if !ok {
+		return nil, func() {}
+	}
+	id := sequencer.NextRequestID()
+	return []client.RequestOption{client.WithJsonRpcRequestId(id)}, func() {
+		if ctx.Err() == nil {
+			return
+		}
+		params := map[string]interface{}{"requestId": id, "reason": context.Cause(ctx).Error()}
+		notification, err := jsonrpc.NewNotification(schema.MethodNotificationCanceled, params)
+		if err == nil {
+			err = item.impl.downstream.Notify(context.WithoutCancel(ctx), notification)
+		}
+		if err != nil {
+			h.warn(ctx, item, schema.MethodNotificationCanceled, err)
+		}
+	}
+}
+
+// Implements indicates which methods are supported by the gateway.
+func (h *gatewayHandler) Implements(method string) bool {
+	switch method {
+	case schema.MethodInitialize,
+		schema.MethodPing,
+		schema.MethodResourcesList,
+		schema.MethodResourcesTemplatesList,
+		schema.MethodResourcesRead,
+		schema.MethodSubscribe,
+		schema.MethodUnsubscribe,
+		schema.MethodPromptsList,
+		schema.MethodPromptsGet,
+		schema.MethodToolsList,
+		schema.MethodToolsCall,
+		schema.MethodComplete:
+		return true
+	}
+	return false
+}
+
+// available returns initialized upstreams whose capabilities match.
+func (h *gatewayHandler) available(match func(c *schema.ServerCapabilities) bool) []*gatewayUpstream {
+	var ret []*gatewayUpstream
+	for _, item := range h.upstreams {
+		if item.err == nil && item.impl.endpoint != nil && match(&item.capabilities) {
+			ret = append(ret, item)
+		}
+	}
+	return ret
+}
+
+// route resolves a prefixed name, refreshing the routing table once when the name is unknown.
+func (h *gatewayHandler) route(ctx context.Context, name string, routes func() map[string]*gatewayRoute, refresh func()) (*gatewayRoute, *jsonrpc.Error) {
+	h.mu.RLock()
+	route, ok := routes()[name]
+	h.mu.RUnlock()
+	if !ok {
+		refresh()
+		h.mu.RLock()
+		route, ok = routes()[name]
+		h.mu.RUnlock()
+	}
+	if !ok {
+		for _, item := range h.upstreams {
+			if item.err != nil && item.prefix != "" && strings.HasPrefix(name, item.prefix) {
+				return nil, jsonrpc.NewInternalError(fmt.Sprintf("upstream %v is unavailable: %v", item.name, item.err), nil)
+			}
+		}
+		return nil, mcpserver.ProtocolError(ctx, jsonrpc.NewInvalidParamsError(fmt.Sprintf("unknown name: %v", name), nil))
+	}
+	return route, nil
+}
+
+// resourceUpstreams returns the upstream listing uri, or all resource upstreams when none does.
+func (h *gatewayHandler) resourceUpstreams(ctx context.Context, uri string) []*gatewayUpstream {
+	h.mu.RLock()
+	item, ok := h.resources[uri]
+	h.mu.RUnlock()
+	if !ok {
+		_, _ = h.ListResources(ctx, nil)
+		h.mu.RLock()
+		item, ok = h.resources[uri]
+		h.mu.RUnlock()
+	}
+	if ok {
+		return []*gatewayUpstream{item}
+	}
+	return h.available(func(c *schema.ServerCapabilities) bool { return c.Resources != nil })
+}
+
+func (h *gatewayHandler) warn(ctx context.Context, item *gatewayUpstream, method string, err error) {
+	if h.logger == nil {
+		return
+	}
+	_ = h.logger.Warning(ctx, fmt.Sprintf("gateway upstream %v: %v: %v", item.name, method, err))
+}
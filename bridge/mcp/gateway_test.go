@@ -0,0 +1,320 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/jsonrpc/transport"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+	"github.com/viant/mcp/client"
+	mcpserver "github.com/viant/mcp/server"
+	"github.com/viant/mcp/server/inprocess"
+)
+
+func newTestUpstream(t *testing.T, name string) func(ctx context.Context, handler transport.Handler) (transport.Transport, error) {
+	newHandler := serverproto.WithDefaultHandler(context.Background(), func(server *serverproto.DefaultHandler) error {
+		server.RegisterToolWithSchema("echo", "echoes upstream name", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				return &schema.CallToolResult{Content: []schema.CallToolResultContentElem{schema.TextContent{Type: "text", Text: name}}}, nil
+			})
+		return nil
+	})
+	srv, err := mcpserver.New(mcpserver.WithNewHandler(newHandler))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return func(ctx context.Context, handler transport.Handler) (transport.Transport, error) {
+		return inprocess.New(ctx, srv.NewHandler, handler), nil
+	}
+}
+
+func TestGateway(t *testing.T) {
+	empty := ""
+	failing := func(ctx context.Context, handler transport.Handler) (transport.Transport, error) {
+		return nil, errors.New("connection refused")
+	}
+	flaky := func(dial func(ctx context.Context, handler transport.Handler) (transport.Transport, error)) func(ctx context.Context, handler transport.Handler) (transport.Transport, error) {
+		attempts := 0
+		return func(ctx context.Context, handler transport.Handler) (transport.Transport, error) {
+			if attempts++; attempts == 1 {
+				return nil, errors.New("connection refused")
+			}
+			return dial(ctx, handler)
+		}
+	}
+	testCases := []struct {
+		name        string
+		upstreams   []*Upstream
+		sessions    int
+		expectTools []string
+		calls       map[string]string
+		unavailable []string
+		expectErr   bool
+	}{
+		{
+			name: "prefixed upstreams",
+			upstreams: []*Upstream{
+				{Name: "alpha", Dial: newTestUpstream(t, "alpha")},
+				{Name: "beta", Dial: newTestUpstream(t, "beta")},
+			},
+			expectTools: []string{"alpha_echo", "beta_echo"},
+			calls:       map[string]string{"alpha_echo": "alpha", "beta_echo": "beta"},
+		},
+		{
+			name: "failing upstream tolerated",
+			upstreams: []*Upstream{
+				{Name: "alpha", Prefix: &empty, Dial: newTestUpstream(t, "alpha")},
+				{Name: "down", Dial: failing},
+			},
+			expectTools: []string{"echo"},
+			calls:       map[string]string{"echo": "alpha"},
+			unavailable: []string{"down_echo"},
+		},
+		{
+			name: "failed upstream redialed by later session",
+			upstreams: []*Upstream{
+				{Name: "alpha", Dial: newTestUpstream(t, "alpha")},
+				{Name: "beta", Dial: flaky(newTestUpstream(t, "beta"))},
+			},
+			sessions:    2,
+			expectTools: []string{"alpha_echo", "beta_echo"},
+			calls:       map[string]string{"alpha_echo": "alpha", "beta_echo": "beta"},
+		},
+		{
+			name:      "all upstreams failing",
+			upstreams: []*Upstream{{Name: "down", Dial: failing}},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			gateway, err := NewGateway(ctx, &GatewayOptions{Upstreams: tc.upstreams})
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			srv, err := gateway.Server()
+			if !assert.NoError(t, err) {
+				return
+			}
+			for i := 1; i < tc.sessions; i++ {
+				_, err = srv.InProcessClient(ctx, nil).Initialize(ctx)
+				assert.NoError(t, err)
+			}
+			cli := srv.InProcessClient(ctx, nil)
+			_, err = cli.Initialize(ctx)
+			if !assert.NoError(t, err) {
+				return
+			}
+			tools, err := cli.ListTools(ctx, nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+			var names []string
+			for _, tool := range tools.Tools {
+				names = append(names, tool.Name)
+			}
+			sort.Strings(names)
+			assert.EqualValues(t, tc.expectTools, names)
+			for name, expect := range tc.calls {
+				result, err := cli.CallTool(ctx, &schema.CallToolRequestParams{Name: name})
+				if !assert.NoError(t, err, name) {
+					continue
+				}
+				assert.EqualValues(t, expect, result.Content[0].(map[string]interface{})["text"], name)
+			}
+			for _, name := range tc.unavailable {
+				result, err := cli.CallTool(ctx, &schema.CallToolRequestParams{Name: name})
+				if !assert.NoError(t, err, name) {
+					continue
+				}
+				assert.True(t, result.IsError != nil && *result.IsError, name)
+			}
+			_, err = cli.CallTool(ctx, &schema.CallToolRequestParams{Name: "missing"})
+			assert.Error(t, err)
+		})
+	}
+}
+
+// detachedTransport returns once the request context is done without cancelling the upstream call,
+// as network transports do.
+type detachedTransport struct {
+	*inprocess.Transport
+}
+
+func (d *detachedTransport) Send(ctx context.Context, request *jsonrpc.Request) (*jsonrpc.Response, error) {
+	type result struct {
+		response *jsonrpc.Response
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		response, err := d.Transport.Send(context.WithoutCancel(ctx), request)
+		done <- result{response: response, err: err}
+	}()
+	select {
+	case ret := <-done:
+		return ret.response, ret.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestGateway_Cancel(t *testing.T) {
+	started := make(chan struct{}, 1)
+	cancelled := make(chan struct{}, 1)
+	newHandler := serverproto.WithDefaultHandler(context.Background(), func(server *serverproto.DefaultHandler) error {
+		server.RegisterToolWithSchema("wait", "waits for cancellation", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				started <- struct{}{}
+				select {
+				case <-ctx.Done():
+					cancelled <- struct{}{}
+				case <-time.After(5 * time.Second):
+				}
+				return &schema.CallToolResult{}, nil
+			})
+		return nil
+	})
+	upstreamServer, err := mcpserver.New(mcpserver.WithNewHandler(newHandler))
+	if !assert.NoError(t, err) {
+		return
+	}
+	ctx := context.Background()
+	gateway, err := NewGateway(ctx, &GatewayOptions{Upstreams: []*Upstream{{Name: "up", Dial: func(ctx context.Context, handler transport.Handler) (transport.Transport, error) {
+		return &detachedTransport{Transport: inprocess.New(ctx, upstreamServer.NewHandler, handler)}, nil
+	}}}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	srv, err := gateway.Server()
+	if !assert.NoError(t, err) {
+		return
+	}
+	cli := srv.InProcessClient(ctx, nil)
+	_, err = cli.Initialize(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+	callCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		_, _ = cli.CallTool(callCtx, &schema.CallToolRequestParams{Name: "up_wait"})
+	}()
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		assert.Fail(t, "upstream tool was not called")
+		return
+	}
+	cancel()
+	select {
+	case <-cancelled:
+	case <-time.After(2 * time.Second):
+		assert.Fail(t, "cancellation was not forwarded upstream")
+	}
+}
+
+// sessionClient answers elicitation with its own name and counts the requests it received.
+type sessionClient struct {
+	name     string
+	mu       sync.Mutex
+	seq      int
+	elicited int
+}
+
+func (c *sessionClient) Notify(ctx context.Context, n *jsonrpc.Notification) error { return nil }
+func (c *sessionClient) NextRequestID() jsonrpc.RequestId                          { c.seq++; return c.seq }
+func (c *sessionClient) LastRequestID() jsonrpc.RequestId                          { return c.seq }
+func (c *sessionClient) Init(ctx context.Context, _ *schema.ClientCapabilities)    {}
+func (c *sessionClient) Implements(method string) bool {
+	return method == schema.MethodElicitationCreate
+}
+func (c *sessionClient) OnNotification(ctx context.Context, notification *jsonrpc.Notification) {}
+
+func (c *sessionClient) ListRoots(ctx context.Context, request *jsonrpc.TypedRequest[*schema.ListRootsRequest]) (*schema.ListRootsResult, *jsonrpc.Error) {
+	return nil, jsonrpc.NewMethodNotFound("roots not supported", nil)
+}
+
+func (c *sessionClient) CreateMessage(ctx context.Context, request *jsonrpc.TypedRequest[*schema.CreateMessageRequest]) (*schema.CreateMessageResult, *jsonrpc.Error) {
+	return nil, jsonrpc.NewMethodNotFound("sampling not supported", nil)
+}
+
+func (c *sessionClient) Elicit(ctx context.Context, request *jsonrpc.TypedRequest[*schema.ElicitRequest]) (*schema.ElicitResult, *jsonrpc.Error) {
+	c.mu.Lock()
+	c.elicited++
+	c.mu.Unlock()
+	return &schema.ElicitResult{Action: schema.ElicitResultActionAccept, Content: map[string]interface{}{"name": c.name}}, nil
+}
+
+func TestGateway_SessionBackCalls(t *testing.T) {
+	newHandler := serverproto.WithDefaultHandler(context.Background(), func(server *serverproto.DefaultHandler) error {
+		server.RegisterToolWithSchema("whoami", "elicits the caller name", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				elicited, rErr := server.Client.Elicit(ctx, &jsonrpc.TypedRequest[*schema.ElicitRequest]{Request: &schema.ElicitRequest{Params: schema.ElicitRequestParams{Message: "name?"}}})
+				if rErr != nil {
+					return nil, rErr
+				}
+				return &schema.CallToolResult{Content: []schema.CallToolResultContentElem{schema.TextContent{Type: "text", Text: elicited.Content["name"].(string)}}}, nil
+			})
+		return nil
+	})
+	upstreamServer, err := mcpserver.New(mcpserver.WithNewHandler(newHandler))
+	if !assert.NoError(t, err) {
+		return
+	}
+	ctx := context.Background()
+	gateway, err := NewGateway(ctx, &GatewayOptions{Upstreams: []*Upstream{{Name: "up", Dial: func(ctx context.Context, handler transport.Handler) (transport.Transport, error) {
+		return inprocess.New(ctx, upstreamServer.NewHandler, handler), nil
+	}}}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	srv, err := gateway.Server()
+	if !assert.NoError(t, err) {
+		return
+	}
+	alice, bob := &sessionClient{name: "alice"}, &sessionClient{name: "bob"}
+	aliceClient := srv.InProcessClient(ctx, alice)
+	_, err = aliceClient.Initialize(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+	bobClient := srv.InProcessClient(ctx, bob)
+	_, err = bobClient.Initialize(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	testCases := []struct {
+		name   string
+		client *client.Client
+		expect string
+	}{
+		{name: "first session", client: aliceClient, expect: "alice"},
+		{name: "second session", client: bobClient, expect: "bob"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.client.CallTool(ctx, &schema.CallToolRequestParams{Name: "up_whoami"})
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.EqualValues(t, tc.expect, result.Content[0].(map[string]interface{})["text"])
+		})
+	}
+	assert.Equal(t, 1, alice.elicited, "alice answered only her own elicitation")
+	assert.Equal(t, 1, bob.elicited, "bob answered only his own elicitation")
+}
@@ -90,6 +90,8 @@ type clientImplementer struct {
 	clientOps protoClient.Operations
 	// clientHandler adapts Operations to pclient.Handler for inbound upstream requests
 	clientHandler protoClient.Handler
+	// initErr holds the error of the last endpoint initialization
+	initErr error
 }
 
 // Initialize proxies the initialize request to the client endpoint.
@@ -104,6 +106,7 @@ func (ci *clientImplementer) Initialize(ctx context.Context, init *schema.Initia
 		client.WithReconnect(ci.reconnect),
 		client.WithCapabilities(schema.ClientCapabilities{Experimental: map[string]map[string]interface{}{}}))
 	res, err := ci.endpoint.Initialize(ctx)
+	ci.initErr = err
 	if err != nil {
 		return
 	}
@@ -164,19 +164,21 @@ func NewClientWithContext(ctx context.Context, handler pclient.Handler, options
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	// Build initial transport and capture a factory for future reconnects.
+	// Build initial transport and capture a factory for future reconnects; transports share the client handler.
+	clientHandler := client.NewHandler(handler)
 	dial := func(ctx context.Context) (transport.Transport, error) {
-		t, _, err := options.getTransport(ctx, handler)
+		t, _, err := options.getTransport(ctx, clientHandler)
 		return t, err
 	}
 
-	rpcTransport, authRT, err := options.getTransport(ctx, handler)
+	rpcTransport, authRT, err := options.getTransport(ctx, clientHandler)
 	if err != nil {
 		return nil, err
 	}
 
 	opts := options.Options(authRT)
 	opts = append(opts, client.WithClientHandler(handler))
+	opts = append(opts, client.WithNotificationHandler(clientHandler))
 	opts = append(opts, client.WithReconnect(dial))
 	// Keepalive ping: use configured interval if provided, else default 60 seconds.
 	pingEvery := 60
@@ -193,7 +195,7 @@ func NewClientWithContext(ctx context.Context, handler pclient.Handler, options
 }
 
 // getTransport constructs a JSON-RPC transport based on ClientOptions.Transport and authentication settings.
-func (c *ClientOptions) getTransport(ctx context.Context, handler pclient.Handler) (transport.Transport, *authtransport.RoundTripper, error) {
+func (c *ClientOptions) getTransport(ctx context.Context, clientHandler *client.Handler) (transport.Transport, *authtransport.RoundTripper, error) {
 	var httpClient *http.Client
 	var authRT *authtransport.RoundTripper
 	// If a pre-built auth transport was injected via SetAuthTransport, reuse it
@@ -248,7 +250,6 @@ func (c *ClientOptions) getTransport(ctx context.Context, handler pclient.Handle
 		httpClient = wrapContextAuthHTTPClient(httpClient)
 	}
 
-	clientHandler := client.NewHandler(handler)
 	switch c.Transport.Type {
 
 	case "stdio":
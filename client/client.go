@@ -27,6 +27,7 @@ type Client struct {
 	transport       transport.Transport // server version
 	initialized     bool
 	clientHandler   pclient.Handler
+	streams         *contentStreams
 	authInterceptor *auth.Authorizer
 	stateMu         sync.RWMutex
 	reconnectMu     sync.Mutex
@@ -131,7 +132,16 @@ func (c *Client) GetPrompt(ctx context.Context, params *schema.GetPromptRequestP
 }
 
 func (c *Client) CallTool(ctx context.Context, params *schema.CallToolRequestParams, options ...RequestOption) (*schema.CallToolResult, error) {
-	return send[schema.CallToolRequestParams, schema.CallToolResult](ctx, c, schema.MethodToolsCall, params, options...)
+	listener := NewRequestOptions(options).ContentListener
+	if listener == nil {
+		return send[schema.CallToolRequestParams, schema.CallToolResult](ctx, c, schema.MethodToolsCall, params, options...)
+	}
+	params, release := c.streams.register(params, listener)
+	result, err := send[schema.CallToolRequestParams, schema.CallToolResult](ctx, c, schema.MethodToolsCall, params, options...)
+	if streamed := release(); !streamed && err == nil && result != nil && len(result.Content) > 0 {
+		listener(ctx, result.Content) // nothing streamed (unbound notification handler or non-streaming tool)
+	}
+	return result, err
 }
 
 func (c *Client) Complete(ctx context.Context, params *schema.CompleteRequestParams, options ...RequestOption) (*schema.CompleteResult, error) {
@@ -305,6 +315,9 @@ func New(name, version string, transport transport.Transport, options ...Option)
 	for _, opt := range options {
 		opt(ret)
 	}
+	if ret.streams == nil { // default when the transport handler is not bound with WithNotificationHandler
+		ret.streams = newContentStreams()
+	}
 
 	if ret.protocolVersion == "" {
 		if aVersioner, ok := ret.clientHandler.(versioner); ok {
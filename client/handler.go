@@ -12,12 +12,13 @@ import (
 
 type Handler struct {
 	handler pclient.Handler
+	streams *contentStreams
 }
 
 func (h *Handler) Serve(ctx context.Context, request *jsonrpc.Request, response *jsonrpc.Response) {
 	response.Id = request.Id
 	response.Jsonrpc = request.Jsonrpc
-	if !h.handler.Implements(request.Method) {
+	if h.handler == nil || !h.handler.Implements(request.Method) {
 		response.Error = jsonrpc.NewMethodNotFound(fmt.Sprintf("method %s not found", request.Method), nil)
 		return
 	}
@@ -37,7 +38,12 @@ func (h *Handler) Serve(ctx context.Context, request *jsonrpc.Request, response
 }
 
 func (s *Handler) OnNotification(ctx context.Context, notification *jsonrpc.Notification) {
-	s.handler.OnNotification(ctx, notification)
+	if notification.Method == schema.MethodNotificationProgress {
+		s.streams.dispatch(ctx, notification)
+	}
+	if s.handler != nil {
+		s.handler.OnNotification(ctx, notification)
+	}
 }
 
 func (s *Handler) setResponse(response *jsonrpc.Response, result interface{}, rpcError *jsonrpc.Error) {
@@ -51,7 +57,7 @@ func (s *Handler) setResponse(response *jsonrpc.Response, result interface{}, rp
 	}
 }
 
-// NewHandler create clientHandler clientHandler
+// NewHandler create clientHandler clientHandler; handler may be nil when the client serves no server requests.
 func NewHandler(handler pclient.Handler) *Handler {
-	return &Handler{handler: handler}
+	return &Handler{handler: handler, streams: newContentStreams()}
 }
@@ -14,6 +14,8 @@ type RequestOptions struct {
 	RequestId      jsonrpc.RequestId
 	JsonrpcVersion string
 	StringToken    string
+	// ContentListener receives partial tool output (CallTool only)
+	ContentListener ContentListener
 }
 
 func NewRequestOptions(options []RequestOption) *RequestOptions {
@@ -68,6 +70,14 @@ func WithClientHandler(handler pclient.Handler) Option {
 	}
 }
 
+// WithNotificationHandler binds the transport handler receiving server notifications of this client;
+// it is required to stream tool content with WithContentListener.
+func WithNotificationHandler(handler *Handler) Option {
+	return func(c *Client) {
+		c.streams = handler.streams
+	}
+}
+
 func WithProtocolVersion(version string) Option {
 	return func(c *Client) {
 		c.protocolVersion = version
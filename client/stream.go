@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"sync/atomic"
+
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	"github.com/viant/mcp-protocol/syncmap"
+)
+
+// partialContentMetaKey mirrors server.PartialContentMetaKey.
+const partialContentMetaKey = "io.viant.mcp/partial-content"
+
+// ContentListener receives content chunks streamed by a tool while the call is in progress.
+type ContentListener func(ctx context.Context, content []schema.CallToolResultContentElem)
+
+// WithContentListener streams partial tool output of CallTool to listener; the final result still carries the whole output.
+// Streamed chunks need the transport handler bound with WithNotificationHandler; when no chunk arrives,
+// listener receives the final result content once the call returns.
+func WithContentListener(listener ContentListener) RequestOption {
+	return func(options *RequestOptions) {
+		options.ContentListener = listener
+	}
+}
+
+// contentStreams routes streamed tool content to listeners by progress token.
+type contentStreams struct {
+	listeners *syncmap.Map[schema.ProgressToken, *contentStream]
+	prefix    int64 // random per-client high bits keeping tokens of different clients apart
+	seq       int64
+}
+
+type contentStream struct {
+	listener ContentListener
+	received atomic.Bool
+}
+
+func newContentStreams() *contentStreams {
+	return &contentStreams{
+		listeners: syncmap.NewMap[schema.ProgressToken, *contentStream](),
+		prefix:    rand.Int63n(1<<21) << 32, // stays within the 2^53 integer range of JSON peers
+	}
+}
+
+// nextToken returns a progress token unique to this client.
+func (s *contentStreams) nextToken() schema.ProgressToken {
+	return schema.ProgressToken(s.prefix | atomic.AddInt64(&s.seq, 1)&(1<<32-1))
+}
+
+// register requests progress notifications for params and registers listener under its progress token.
+// The returned release func unregisters listener and reports whether any content was streamed to it.
+func (s *contentStreams) register(params *schema.CallToolRequestParams, listener ContentListener) (*schema.CallToolRequestParams, func() bool) {
+	ret := *params
+	meta := schema.CallToolRequestParamsMeta{}
+	if ret.Meta != nil {
+		meta = *ret.Meta
+	}
+	if meta.ProgressToken == nil {
+		token := s.nextToken()
+		meta.ProgressToken = &token
+	}
+	ret.Meta = &meta
+	token := *meta.ProgressToken
+	stream := &contentStream{listener: listener}
+	s.listeners.Put(token, stream)
+	return &ret, func() bool {
+		s.listeners.Delete(token)
+		return stream.received.Load()
+	}
+}
+
+// dispatch passes streamed content of a progress notification to the registered listener.
+func (s *contentStreams) dispatch(ctx context.Context, notification *jsonrpc.Notification) {
+	params := &struct {
+		Meta          map[string]json.RawMessage `json:"_meta"`
+		ProgressToken schema.ProgressToken       `json:"progressToken"`
+	}{}
+	if err := json.Unmarshal(notification.Params, params); err != nil {
+		return
+	}
+	data, ok := params.Meta[partialContentMetaKey]
+	if !ok {
+		return
+	}
+	stream, ok := s.listeners.Get(params.ProgressToken)
+	if !ok {
+		return
+	}
+	var content []schema.CallToolResultContentElem
+	if err := json.Unmarshal(data, &content); err != nil {
+		return
+	}
+	stream.received.Store(true)
+	stream.listener(ctx, content)
+}
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+func TestContentStreams_Register(t *testing.T) {
+	listener := func(ctx context.Context, content []schema.CallToolResultContentElem) {}
+	explicit := schema.ProgressToken(7)
+	testCases := []struct {
+		name        string
+		params      *schema.CallToolRequestParams
+		expectToken *schema.ProgressToken
+	}{
+		{name: "generated token", params: &schema.CallToolRequestParams{Name: "tool"}},
+		{name: "caller token kept", params: &schema.CallToolRequestParams{Name: "tool", Meta: &schema.CallToolRequestParamsMeta{ProgressToken: &explicit}}, expectToken: &explicit},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			first, second := newContentStreams(), newContentStreams()
+			firstParams, release := first.register(testCase.params, listener)
+			defer release()
+			secondParams, releaseSecond := second.register(testCase.params, listener)
+			defer releaseSecond()
+			if testCase.expectToken != nil {
+				assert.Equal(t, *testCase.expectToken, *firstParams.Meta.ProgressToken)
+				return
+			}
+			assert.NotEqual(t, *firstParams.Meta.ProgressToken, *secondParams.Meta.ProgressToken)
+			assert.Less(t, int64(*firstParams.Meta.ProgressToken), int64(1)<<53)
+		})
+	}
+}
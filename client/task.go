@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/viant/mcp-protocol/schema"
+)
+
+const (
+	methodTasksGet    = "tasks/get"
+	methodTasksResult = "tasks/result"
+	methodTasksCancel = "tasks/cancel"
+	methodTasksList   = "tasks/list"
+
+	relatedTaskMetaKey = "io.modelcontextprotocol/related-task"
+)
+
+// CallToolAsTask requests task-augmented execution of a tool; ttl is the requested retention in milliseconds.
+func (c *Client) CallToolAsTask(ctx context.Context, params *schema.CallToolRequestParams, ttl *int, options ...RequestOption) (*schema.CreateTaskResult, error) {
+	augmented := *params
+	augmented.Task = &schema.TaskMetadata{Ttl: ttl}
+	return send[schema.CallToolRequestParams, schema.CreateTaskResult](ctx, c, schema.MethodToolsCall, &augmented, options...)
+}
+
+// GetTask returns the current state of a task.
+func (c *Client) GetTask(ctx context.Context, taskID string, options ...RequestOption) (*schema.Task, error) {
+	params := &schema.GetTaskRequestParams{TaskId: taskID}
+	return send[schema.GetTaskRequestParams, schema.Task](ctx, c, methodTasksGet, params, options...)
+}
+
+// GetTaskResult returns the tool result of a task, blocking on the server until the task finishes.
+func (c *Client) GetTaskResult(ctx context.Context, taskID string, options ...RequestOption) (*schema.CallToolResult, error) {
+	params := &schema.GetTaskPayloadRequestParams{TaskId: taskID}
+	return send[schema.GetTaskPayloadRequestParams, schema.CallToolResult](ctx, c, methodTasksResult, params, options...)
+}
+
+// CancelTask cancels a running task.
+func (c *Client) CancelTask(ctx context.Context, taskID string, options ...RequestOption) (*schema.Task, error) {
+	params := &schema.CancelTaskRequestParams{TaskId: taskID}
+	return send[schema.CancelTaskRequestParams, schema.Task](ctx, c, methodTasksCancel, params, options...)
+}
+
+// ListTasks lists tasks of the current session.
+func (c *Client) ListTasks(ctx context.Context, cursor *string, options ...RequestOption) (*schema.ListTasksResult, error) {
+	params := &schema.PaginatedRequestParams{Cursor: cursor}
+	return send[schema.PaginatedRequestParams, schema.ListTasksResult](ctx, c, methodTasksList, params, options...)
+}
+
+// AwaitTask polls a task until it reaches a terminal status and returns its result.
+// interval overrides the poll interval suggested by the server when positive.
+func (c *Client) AwaitTask(ctx context.Context, taskID string, interval time.Duration, options ...RequestOption) (*schema.CallToolResult, error) {
+	for {
+		aTask, err := c.GetTask(ctx, taskID, options...)
+		if err != nil {
+			return nil, err
+		}
+		switch aTask.Status {
+		case schema.TaskStatusCompleted, schema.TaskStatusFailed, schema.TaskStatusCancelled:
+			return c.GetTaskResult(ctx, taskID, options...)
+		}
+		wait := interval
+		if wait <= 0 && aTask.PollInterval != nil {
+			wait = time.Duration(*aTask.PollInterval) * time.Millisecond
+		}
+		if wait <= 0 {
+			wait = time.Second
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// RelatedTaskID returns the task id referenced by a tool result, if any.
+func RelatedTaskID(result *schema.CallToolResult) string {
+	if result == nil || result.Meta == nil {
+		return ""
+	}
+	data, err := json.Marshal(result.Meta[relatedTaskMetaKey])
+	if err != nil {
+		return ""
+	}
+	related := struct {
+		TaskId string `json:"taskId"`
+	}{}
+	if json.Unmarshal(data, &related) != nil {
+		return ""
+	}
+	return related.TaskId
+}
@@ -13,6 +13,7 @@ require (
 	github.com/viant/mcp-protocol v0.14.0
 	github.com/viant/scy v0.24.0
 	golang.org/x/oauth2 v0.30.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -33,7 +34,6 @@ require (
 	golang.org/x/net v0.46.0 // indirect
 	golang.org/x/sys v0.37.0 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
 replace github.com/viant/jsonrpc => /Users/awitas/go/src/github.com/viant/jsonrpc
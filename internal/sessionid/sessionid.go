@@ -0,0 +1,26 @@
+// Package sessionid resolves the transport session id carried by a request context.
+package sessionid
+
+import (
+	"context"
+
+	"github.com/viant/jsonrpc"
+	"github.com/viant/jsonrpc/transport/server/base"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+// FromContext returns the transport session id carried by ctx, or empty when there is none.
+func FromContext(ctx context.Context) string {
+	switch actual := ctx.Value(jsonrpc.SessionKey).(type) {
+	case *base.Session:
+		if actual != nil {
+			return actual.Id
+		}
+	case string:
+		return actual
+	}
+	if value, ok := ctx.Value(schema.McpSessionContextKey).(string); ok {
+		return value
+	}
+	return ""
+}
@@ -0,0 +1,161 @@
+package mcptest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/viant/jsonrpc"
+	"github.com/viant/jsonrpc/transport"
+	"github.com/viant/jsonrpc/transport/client/http/streamable"
+	serverproto "github.com/viant/mcp-protocol/server"
+	"github.com/viant/mcp/server"
+)
+
+// Dialer opens a connection to the server under test.
+type Dialer func(ctx context.Context) (*Conn, error)
+
+// Handler returns a dialer running newHandler in-process.
+func Handler(newHandler serverproto.NewHandler, options ...server.Option) Dialer {
+	return func(ctx context.Context) (*Conn, error) {
+		srv, err := server.New(append([]server.Option{server.WithNewHandler(newHandler)}, options...)...)
+		if err != nil {
+			return nil, err
+		}
+		conn := newConn()
+		handler := srv.NewHandler(ctx, &serverSide{conn: conn})
+		conn.transport = &handlerTransport{handler: handler}
+		return conn, nil
+	}
+}
+
+// URL returns a dialer connecting to a streamable HTTP endpoint.
+func URL(endpoint string, options ...streamable.Option) Dialer {
+	return func(ctx context.Context) (*Conn, error) {
+		conn := newConn()
+		aClient, err := streamable.New(ctx, endpoint, append([]streamable.Option{streamable.WithHandler(&serverSide{conn: conn})}, options...)...)
+		if err != nil {
+			return nil, err
+		}
+		conn.transport = aClient
+		conn.closer = aClient.Close
+		return conn, nil
+	}
+}
+
+// Conn is a raw JSON-RPC connection to the server under test.
+type Conn struct {
+	transport     transport.Transport
+	closer        func() error
+	seq           int64
+	mu            sync.Mutex
+	notifications []*jsonrpc.Notification
+}
+
+func newConn() *Conn {
+	return &Conn{}
+}
+
+// NewRequest creates a request with the next request id.
+func (c *Conn) NewRequest(method string, params interface{}) (*jsonrpc.Request, error) {
+	request, err := jsonrpc.NewRequest(method, params)
+	if err != nil {
+		return nil, err
+	}
+	request.Id = int(atomic.AddInt64(&c.seq, 1))
+	return request, nil
+}
+
+// Send sends a request and returns the raw response.
+func (c *Conn) Send(ctx context.Context, request *jsonrpc.Request) (*jsonrpc.Response, error) {
+	return c.transport.Send(ctx, request)
+}
+
+// Call creates and sends a request.
+func (c *Conn) Call(ctx context.Context, method string, params interface{}) (*jsonrpc.Response, error) {
+	request, err := c.NewRequest(method, params)
+	if err != nil {
+		return nil, err
+	}
+	return c.Send(ctx, request)
+}
+
+// Notify sends a notification to the server.
+func (c *Conn) Notify(ctx context.Context, method string, params interface{}) error {
+	notification, err := jsonrpc.NewNotification(method, params)
+	if err != nil {
+		return err
+	}
+	return c.transport.Notify(ctx, notification)
+}
+
+// Notifications returns notifications received so far with the supplied method.
+func (c *Conn) Notifications(method string) []*jsonrpc.Notification {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var ret []*jsonrpc.Notification
+	for _, notification := range c.notifications {
+		if notification.Method == method {
+			ret = append(ret, notification)
+		}
+	}
+	return ret
+}
+
+// Close closes the connection.
+func (c *Conn) Close() error {
+	if c.closer == nil {
+		return nil
+	}
+	return c.closer()
+}
+
+func (c *Conn) received(notification *jsonrpc.Notification) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.notifications = append(c.notifications, notification)
+}
+
+// handlerTransport sends client messages directly to an in-process handler.
+type handlerTransport struct {
+	handler transport.Handler
+}
+
+func (t *handlerTransport) Send(ctx context.Context, request *jsonrpc.Request) (*jsonrpc.Response, error) {
+	response := &jsonrpc.Response{Id: request.Id, Jsonrpc: request.Jsonrpc}
+	t.handler.Serve(ctx, request, response)
+	return response, nil
+}
+
+func (t *handlerTransport) Notify(ctx context.Context, notification *jsonrpc.Notification) error {
+	t.handler.OnNotification(ctx, notification)
+	return nil
+}
+
+// serverSide collects server notifications and rejects server-to-client requests,
+// since the kit does not advertise any client capability.
+type serverSide struct {
+	conn *Conn
+}
+
+func (s *serverSide) Send(ctx context.Context, request *jsonrpc.Request) (*jsonrpc.Response, error) {
+	return &jsonrpc.Response{Id: request.Id, Jsonrpc: jsonrpc.Version, Error: s.methodNotFound(request.Method)}, nil
+}
+
+func (s *serverSide) Notify(ctx context.Context, notification *jsonrpc.Notification) error {
+	s.conn.received(notification)
+	return nil
+}
+
+func (s *serverSide) Serve(ctx context.Context, request *jsonrpc.Request, response *jsonrpc.Response) {
+	response.Error = s.methodNotFound(request.Method)
+}
+
+func (s *serverSide) OnNotification(ctx context.Context, notification *jsonrpc.Notification) {
+	s.conn.received(notification)
+}
+
+func (s *serverSide) methodNotFound(method string) *jsonrpc.Error {
+	return jsonrpc.NewMethodNotFound(fmt.Sprintf("method: %v not supported by mcptest client", method), nil)
+}
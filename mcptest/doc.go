@@ -0,0 +1,17 @@
+// Package mcptest provides a conformance test kit for MCP servers.
+//
+// The kit exercises the protocol surface of a server (initialize, ping,
+// list/read/call with pagination, cancellation, progress, logging level,
+// subscriptions, error shapes and capabilities consistency) and reports
+// spec-compliance failures either as testing.T errors (Run) or as a Report
+// (Check).
+//
+// A server under test is reached through a Dialer: Handler runs a
+// server.NewHandler in-process, URL connects to a running streamable HTTP
+// endpoint.
+//
+//	func TestConformance(t *testing.T) {
+//		mcptest.Run(t, mcptest.Handler(newHandler),
+//			mcptest.WithToolCall("add", map[string]interface{}{"a": 1, "b": 2}))
+//	}
+package mcptest
@@ -0,0 +1,104 @@
+package mcptest
+
+import (
+	"time"
+
+	"github.com/viant/mcp-protocol/schema"
+)
+
+// ToolCall describes a tool invocation exercised by the kit.
+type ToolCall struct {
+	Name      string
+	Arguments map[string]interface{}
+}
+
+type options struct {
+	protocolVersion string
+	timeout         time.Duration
+	maxPages        int
+	readLimit       int
+	toolCalls       []*ToolCall
+	cancellation    *ToolCall
+	cancelAfter     time.Duration
+	progress        *ToolCall
+	skip            map[string]bool
+}
+
+func newOptions(opts []Option) *options {
+	ret := &options{
+		protocolVersion: schema.LatestProtocolVersion,
+		timeout:         5 * time.Second,
+		maxPages:        50,
+		readLimit:       10,
+		cancelAfter:     100 * time.Millisecond,
+		skip:            map[string]bool{},
+	}
+	for _, opt := range opts {
+		opt(ret)
+	}
+	return ret
+}
+
+// Option customizes the conformance run.
+type Option func(o *options)
+
+// WithProtocolVersion sets the protocol version requested on initialize (default latest).
+func WithProtocolVersion(version string) Option {
+	return func(o *options) {
+		o.protocolVersion = version
+	}
+}
+
+// WithTimeout sets the per-request timeout (default 5s).
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithMaxPages limits how many pages are followed per list method (default 50).
+func WithMaxPages(pages int) Option {
+	return func(o *options) {
+		o.maxPages = pages
+	}
+}
+
+// WithReadLimit limits how many listed resources and prompts are read (default 10).
+func WithReadLimit(limit int) Option {
+	return func(o *options) {
+		o.readLimit = limit
+	}
+}
+
+// WithToolCall adds a tool call whose result shape is verified.
+func WithToolCall(name string, arguments map[string]interface{}) Option {
+	return func(o *options) {
+		o.toolCalls = append(o.toolCalls, &ToolCall{Name: name, Arguments: arguments})
+	}
+}
+
+// WithCancellation enables the cancellation check with a tool call that runs until its context is cancelled.
+func WithCancellation(name string, arguments map[string]interface{}, after time.Duration) Option {
+	return func(o *options) {
+		o.cancellation = &ToolCall{Name: name, Arguments: arguments}
+		if after > 0 {
+			o.cancelAfter = after
+		}
+	}
+}
+
+// WithProgress enables the progress check with a tool call that reports progress.
+func WithProgress(name string, arguments map[string]interface{}) Option {
+	return func(o *options) {
+		o.progress = &ToolCall{Name: name, Arguments: arguments}
+	}
+}
+
+// WithSkip skips checks by name (e.g. "resources/subscribe").
+func WithSkip(checks ...string) Option {
+	return func(o *options) {
+		for _, check := range checks {
+			o.skip[check] = true
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package mcptest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Result holds the outcome of a single check.
+type Result struct {
+	Name     string   `json:"name"`
+	Failures []string `json:"failures,omitempty"`
+	Skipped  string   `json:"skipped,omitempty"`
+}
+
+// Passed reports whether the check ran without failures.
+func (r *Result) Passed() bool {
+	return r.Skipped == "" && len(r.Failures) == 0
+}
+
+func (r *Result) errorf(format string, args ...interface{}) {
+	r.Failures = append(r.Failures, fmt.Sprintf(format, args...))
+}
+
+func (r *Result) skip(reason string) {
+	r.Skipped = reason
+}
+
+// Report holds the outcome of a conformance run.
+type Report struct {
+	Results []*Result `json:"results"`
+}
+
+// Failed reports whether any check failed.
+func (r *Report) Failed() bool {
+	for _, result := range r.Results {
+		if len(result.Failures) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Result returns the result of the named check.
+func (r *Report) Result(name string) *Result {
+	for _, result := range r.Results {
+		if result.Name == name {
+			return result
+		}
+	}
+	return nil
+}
+
+// String returns a human readable summary.
+func (r *Report) String() string {
+	builder := strings.Builder{}
+	for _, result := range r.Results {
+		switch {
+		case result.Skipped != "":
+			builder.WriteString(fmt.Sprintf("SKIP %v: %v\n", result.Name, result.Skipped))
+		case len(result.Failures) == 0:
+			builder.WriteString(fmt.Sprintf("PASS %v\n", result.Name))
+		default:
+			builder.WriteString(fmt.Sprintf("FAIL %v\n", result.Name))
+			for _, failure := range result.Failures {
+				builder.WriteString("     " + failure + "\n")
+			}
+		}
+	}
+	return builder.String()
+}
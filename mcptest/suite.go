@@ -0,0 +1,670 @@
+package mcptest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+// Check names reported by the kit.
+const (
+	CheckInitialize        = "initialize"
+	CheckPing              = "ping"
+	CheckErrors            = "errors"
+	CheckCapabilities      = "capabilities"
+	CheckToolsList         = "tools/list"
+	CheckToolsCall         = "tools/call"
+	CheckResourcesList     = "resources/list"
+	CheckResourcesRead     = "resources/read"
+	CheckTemplatesList     = "resources/templates/list"
+	CheckPromptsList       = "prompts/list"
+	CheckPromptsGet        = "prompts/get"
+	CheckSubscribe         = "resources/subscribe"
+	CheckLoggingSetLevel   = "logging/setLevel"
+	CheckCancellation      = "cancellation"
+	CheckProgress          = "progress"
+	unknownMethod          = "mcptest/unknown"
+	progressToken          = 7
+	progressWait           = 50 * time.Millisecond
+	defaultCancelledReason = "cancelled by mcptest"
+)
+
+// Run exercises the server reached by dial and reports failures as t errors, one subtest per check.
+func Run(t *testing.T, dial Dialer, opts ...Option) *Report {
+	t.Helper()
+	report, err := Check(context.Background(), dial, opts...)
+	if err != nil {
+		t.Fatalf("mcptest: %v", err)
+		return report
+	}
+	for _, result := range report.Results {
+		result := result
+		t.Run(result.Name, func(t *testing.T) {
+			if result.Skipped != "" {
+				t.Skip(result.Skipped)
+			}
+			for _, failure := range result.Failures {
+				t.Error(failure)
+			}
+		})
+	}
+	return report
+}
+
+// Check exercises the server reached by dial and returns the conformance report.
+func Check(ctx context.Context, dial Dialer, opts ...Option) (*Report, error) {
+	conn, err := dial(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close()
+	s := &suite{conn: conn, options: newOptions(opts), report: &Report{}}
+	s.run(ctx)
+	return s.report, nil
+}
+
+type suite struct {
+	conn         *Conn
+	options      *options
+	report       *Report
+	initialized  bool
+	capabilities schema.ServerCapabilities
+	tools        []schema.Tool
+	resources    []schema.Resource
+	prompts      []schema.Prompt
+}
+
+type check struct {
+	name string
+	run  func(ctx context.Context, result *Result)
+}
+
+func (s *suite) run(ctx context.Context) {
+	checks := []check{
+		{CheckInitialize, s.checkInitialize},
+		{CheckPing, s.checkPing},
+		{CheckErrors, s.checkErrors},
+		{CheckToolsList, s.checkToolsList},
+		{CheckResourcesList, s.checkResourcesList},
+		{CheckTemplatesList, s.checkTemplatesList},
+		{CheckPromptsList, s.checkPromptsList},
+		{CheckCapabilities, s.checkCapabilities},
+		{CheckToolsCall, s.checkToolsCall},
+		{CheckResourcesRead, s.checkResourcesRead},
+		{CheckPromptsGet, s.checkPromptsGet},
+		{CheckSubscribe, s.checkSubscribe},
+		{CheckLoggingSetLevel, s.checkLoggingSetLevel},
+		{CheckCancellation, s.checkCancellation},
+		{CheckProgress, s.checkProgress},
+	}
+	for _, item := range checks {
+		result := &Result{Name: item.name}
+		s.report.Results = append(s.report.Results, result)
+		switch {
+		case s.options.skip[item.name]:
+			result.skip("skipped by option")
+		case item.name != CheckInitialize && !s.initialized:
+			result.skip("server was not initialized")
+		default:
+			item.run(ctx, result)
+		}
+	}
+}
+
+// call sends a request with the configured timeout and verifies the JSON-RPC envelope.
+func (s *suite) call(ctx context.Context, result *Result, method string, params interface{}) (*jsonrpc.Response, bool) {
+	request, err := s.conn.NewRequest(method, params)
+	if err != nil {
+		result.errorf("%v: failed to create request: %v", method, err)
+		return nil, false
+	}
+	return s.send(ctx, result, request)
+}
+
+func (s *suite) send(ctx context.Context, result *Result, request *jsonrpc.Request) (*jsonrpc.Response, bool) {
+	ctx, cancel := context.WithTimeout(ctx, s.options.timeout)
+	defer cancel()
+	response, err := s.conn.Send(ctx, request)
+	if err != nil {
+		result.errorf("%v: transport error: %v", request.Method, err)
+		return nil, false
+	}
+	if response == nil {
+		result.errorf("%v: no response", request.Method)
+		return nil, false
+	}
+	if requestKey(response.Id) != requestKey(request.Id) {
+		result.errorf("%v: response id %v does not match request id %v", request.Method, response.Id, request.Id)
+	}
+	if response.Error != nil {
+		checkErrorShape(result, request.Method, response.Error)
+	}
+	return response, true
+}
+
+// expectResult decodes a successful result into target.
+func expectResult(result *Result, method string, response *jsonrpc.Response, target interface{}) bool {
+	if response.Error != nil {
+		result.errorf("%v: unexpected error %v: %v", method, response.Error.Code, response.Error.Message)
+		return false
+	}
+	if len(response.Result) == 0 || string(response.Result) == "null" {
+		result.errorf("%v: missing result", method)
+		return false
+	}
+	if err := json.Unmarshal(response.Result, target); err != nil {
+		result.errorf("%v: invalid result: %v", method, err)
+		return false
+	}
+	return true
+}
+
+func checkErrorShape(result *Result, method string, err *jsonrpc.Error) {
+	if err.Code == 0 {
+		result.errorf("%v: error code must be set", method)
+	}
+	if err.Message == "" {
+		result.errorf("%v: error message must be set", method)
+	}
+}
+
+func (s *suite) checkInitialize(ctx context.Context, result *Result) {
+	params := &schema.InitializeRequestParams{
+		ProtocolVersion: s.options.protocolVersion,
+		ClientInfo:      schema.Implementation{Name: "mcptest", Version: "0.1"},
+	}
+	response, ok := s.call(ctx, result, schema.MethodInitialize, params)
+	if !ok {
+		return
+	}
+	raw := map[string]json.RawMessage{}
+	if !expectResult(result, schema.MethodInitialize, response, &raw) {
+		return
+	}
+	for _, key := range []string{"protocolVersion", "capabilities", "serverInfo"} {
+		if _, ok := raw[key]; !ok {
+			result.errorf("initialize: result is missing %q", key)
+		}
+	}
+	initResult := &schema.InitializeResult{}
+	if err := json.Unmarshal(response.Result, initResult); err != nil {
+		result.errorf("initialize: invalid result: %v", err)
+		return
+	}
+	if initResult.ProtocolVersion == "" {
+		result.errorf("initialize: protocolVersion must be set")
+	}
+	if initResult.ServerInfo.Name == "" {
+		result.errorf("initialize: serverInfo.name must be set")
+	}
+	if initResult.ServerInfo.Version == "" {
+		result.errorf("initialize: serverInfo.version must be set")
+	}
+	s.capabilities = initResult.Capabilities
+	s.initialized = true
+	if err := s.conn.Notify(ctx, schema.MethodNotificationInitialized, map[string]interface{}{}); err != nil {
+		result.errorf("%v: %v", schema.MethodNotificationInitialized, err)
+	}
+}
+
+func (s *suite) checkPing(ctx context.Context, result *Result) {
+	response, ok := s.call(ctx, result, schema.MethodPing, &schema.PingRequestParams{})
+	if !ok {
+		return
+	}
+	raw := map[string]interface{}{}
+	expectResult(result, schema.MethodPing, response, &raw)
+}
+
+func (s *suite) checkErrors(ctx context.Context, result *Result) {
+	response, ok := s.call(ctx, result, unknownMethod, map[string]interface{}{})
+	if ok {
+		switch {
+		case response.Error == nil:
+			result.errorf("%v: expected method not found error", unknownMethod)
+		case response.Error.Code != jsonrpc.MethodNotFound:
+			result.errorf("%v: expected error code %v, got %v", unknownMethod, jsonrpc.MethodNotFound, response.Error.Code)
+		}
+	}
+	if s.capabilities.Tools == nil {
+		return
+	}
+	response, ok = s.call(ctx, result, schema.MethodToolsCall, &schema.CallToolRequestParams{Name: "mcptest-unknown-tool"})
+	if !ok || response.Error != nil {
+		return
+	}
+	callResult := &schema.CallToolResult{}
+	if !expectResult(result, schema.MethodToolsCall, response, callResult) {
+		return
+	}
+	if callResult.IsError == nil || !*callResult.IsError {
+		result.errorf("%v: unknown tool must return an error or a result with isError", schema.MethodToolsCall)
+	}
+}
+
+// list follows nextCursor until exhausted and returns raw pages.
+func (s *suite) list(ctx context.Context, result *Result, method string, decode func(data []byte) ([]string, *string, error)) bool {
+	seen := map[string]bool{}
+	cursors := map[string]bool{}
+	var cursor *string
+	for page := 0; page < s.options.maxPages; page++ {
+		params := map[string]interface{}{}
+		if cursor != nil {
+			params["cursor"] = *cursor
+		}
+		response, ok := s.call(ctx, result, method, params)
+		if !ok {
+			return false
+		}
+		if response.Error != nil {
+			result.errorf("%v: unexpected error %v: %v", method, response.Error.Code, response.Error.Message)
+			return false
+		}
+		keys, next, err := decode(response.Result)
+		if err != nil {
+			result.errorf("%v: invalid result: %v", method, err)
+			return false
+		}
+		for _, key := range keys {
+			if seen[key] {
+				result.errorf("%v: duplicate item %q across pages", method, key)
+			}
+			seen[key] = true
+		}
+		if next == nil || *next == "" {
+			return true
+		}
+		if cursors[*next] {
+			result.errorf("%v: nextCursor %q repeats", method, *next)
+			return false
+		}
+		cursors[*next] = true
+		cursor = next
+	}
+	result.errorf("%v: more than %v pages", method, s.options.maxPages)
+	return false
+}
+
+func (s *suite) checkToolsList(ctx context.Context, result *Result) {
+	if s.capabilities.Tools == nil {
+		result.skip("tools capability not advertised")
+		return
+	}
+	s.list(ctx, result, schema.MethodToolsList, func(data []byte) ([]string, *string, error) {
+		page := &schema.ListToolsResult{}
+		if err := json.Unmarshal(data, page); err != nil {
+			return nil, nil, err
+		}
+		var keys []string
+		for _, tool := range page.Tools {
+			if tool.Name == "" {
+				result.errorf("%v: tool name must be set", schema.MethodToolsList)
+			}
+			if tool.InputSchema.Type != "object" {
+				result.errorf("%v: tool %q inputSchema.type must be \"object\", got %q", schema.MethodToolsList, tool.Name, tool.InputSchema.Type)
+			}
+			keys = append(keys, tool.Name)
+			s.tools = append(s.tools, tool)
+		}
+		return keys, page.NextCursor, nil
+	})
+}
+
+func (s *suite) checkResourcesList(ctx context.Context, result *Result) {
+	if s.capabilities.Resources == nil {
+		result.skip("resources capability not advertised")
+		return
+	}
+	s.list(ctx, result, schema.MethodResourcesList, func(data []byte) ([]string, *string, error) {
+		page := &schema.ListResourcesResult{}
+		if err := json.Unmarshal(data, page); err != nil {
+			return nil, nil, err
+		}
+		var keys []string
+		for _, resource := range page.Resources {
+			if resource.Uri == "" {
+				result.errorf("%v: resource uri must be set", schema.MethodResourcesList)
+			}
+			if resource.Name == "" {
+				result.errorf("%v: resource %q name must be set", schema.MethodResourcesList, resource.Uri)
+			}
+			keys = append(keys, resource.Uri)
+			s.resources = append(s.resources, resource)
+		}
+		return keys, page.NextCursor, nil
+	})
+}
+
+func (s *suite) checkTemplatesList(ctx context.Context, result *Result) {
+	if s.capabilities.Resources == nil {
+		result.skip("resources capability not advertised")
+		return
+	}
+	s.list(ctx, result, schema.MethodResourcesTemplatesList, func(data []byte) ([]string, *string, error) {
+		page := &schema.ListResourceTemplatesResult{}
+		if err := json.Unmarshal(data, page); err != nil {
+			return nil, nil, err
+		}
+		var keys []string
+		for _, template := range page.ResourceTemplates {
+			if template.UriTemplate == "" {
+				result.errorf("%v: uriTemplate must be set", schema.MethodResourcesTemplatesList)
+			}
+			if template.Name == "" {
+				result.errorf("%v: template %q name must be set", schema.MethodResourcesTemplatesList, template.UriTemplate)
+			}
+			keys = append(keys, template.UriTemplate)
+		}
+		return keys, page.NextCursor, nil
+	})
+}
+
+func (s *suite) checkPromptsList(ctx context.Context, result *Result) {
+	if s.capabilities.Prompts == nil {
+		result.skip("prompts capability not advertised")
+		return
+	}
+	s.list(ctx, result, schema.MethodPromptsList, func(data []byte) ([]string, *string, error) {
+		page := &schema.ListPromptsResult{}
+		if err := json.Unmarshal(data, page); err != nil {
+			return nil, nil, err
+		}
+		var keys []string
+		for _, prompt := range page.Prompts {
+			if prompt.Name == "" {
+				result.errorf("%v: prompt name must be set", schema.MethodPromptsList)
+			}
+			keys = append(keys, prompt.Name)
+			s.prompts = append(s.prompts, prompt)
+		}
+		return keys, page.NextCursor, nil
+	})
+}
+
+// checkCapabilities verifies that unadvertised features do not expose items.
+func (s *suite) checkCapabilities(ctx context.Context, result *Result) {
+	unadvertised := map[string]bool{
+		schema.MethodToolsList:     s.capabilities.Tools == nil,
+		schema.MethodResourcesList: s.capabilities.Resources == nil,
+		schema.MethodPromptsList:   s.capabilities.Prompts == nil,
+	}
+	for _, method := range []string{schema.MethodToolsList, schema.MethodResourcesList, schema.MethodPromptsList} {
+		if !unadvertised[method] {
+			continue
+		}
+		response, ok := s.call(ctx, result, method, map[string]interface{}{})
+		if !ok || response.Error != nil {
+			continue
+		}
+		items := map[string][]json.RawMessage{}
+		if err := json.Unmarshal(response.Result, &items); err != nil {
+			continue
+		}
+		for key, values := range items {
+			if len(values) > 0 {
+				result.errorf("%v returned %v %v but the capability is not advertised", method, len(values), key)
+			}
+		}
+	}
+	if s.capabilities.Logging != nil {
+		response, ok := s.call(ctx, result, schema.MethodLoggingSetLevel, &schema.SetLevelRequestParams{Level: schema.LoggingLevelInfo})
+		if ok && response.Error != nil {
+			result.errorf("logging capability is advertised but %v failed: %v", schema.MethodLoggingSetLevel, response.Error.Message)
+		}
+	}
+}
+
+func (s *suite) checkToolsCall(ctx context.Context, result *Result) {
+	if len(s.options.toolCalls) == 0 {
+		result.skip("no tool calls configured, use WithToolCall")
+		return
+	}
+	tools := map[string]schema.Tool{}
+	for _, tool := range s.tools {
+		tools[tool.Name] = tool
+	}
+	for _, call := range s.options.toolCalls {
+		tool, ok := tools[call.Name]
+		if !ok {
+			result.errorf("%v: tool %q is not listed", schema.MethodToolsCall, call.Name)
+		}
+		response, ok := s.call(ctx, result, schema.MethodToolsCall, &schema.CallToolRequestParams{Name: call.Name, Arguments: call.Arguments})
+		if !ok {
+			continue
+		}
+		raw := map[string]json.RawMessage{}
+		if !expectResult(result, schema.MethodToolsCall, response, &raw) {
+			continue
+		}
+		content, ok := raw["content"]
+		if !ok || string(content) == "null" {
+			result.errorf("%v: tool %q result must include content array", schema.MethodToolsCall, call.Name)
+		}
+		if tool.OutputSchema != nil {
+			callResult := &schema.CallToolResult{}
+			_ = json.Unmarshal(response.Result, callResult)
+			isError := callResult.IsError != nil && *callResult.IsError
+			if !isError && callResult.StructuredContent == nil {
+				result.errorf("%v: tool %q declares outputSchema but returned no structuredContent", schema.MethodToolsCall, call.Name)
+			}
+		}
+	}
+}
+
+func (s *suite) checkResourcesRead(ctx context.Context, result *Result) {
+	if len(s.resources) == 0 {
+		result.skip("no resources listed")
+		return
+	}
+	for i, resource := range s.resources {
+		if i >= s.options.readLimit {
+			break
+		}
+		response, ok := s.call(ctx, result, schema.MethodResourcesRead, &schema.ReadResourceRequestParams{Uri: resource.Uri})
+		if !ok {
+			continue
+		}
+		raw := &struct {
+			Contents []map[string]interface{} `json:"contents"`
+		}{}
+		if !expectResult(result, schema.MethodResourcesRead, response, raw) {
+			continue
+		}
+		if len(raw.Contents) == 0 {
+			result.errorf("%v: %q returned no contents", schema.MethodResourcesRead, resource.Uri)
+		}
+		for _, item := range raw.Contents {
+			if uri, _ := item["uri"].(string); uri == "" {
+				result.errorf("%v: %q content uri must be set", schema.MethodResourcesRead, resource.Uri)
+			}
+			_, hasText := item["text"]
+			_, hasBlob := item["blob"]
+			if !hasText && !hasBlob {
+				result.errorf("%v: %q content must have text or blob", schema.MethodResourcesRead, resource.Uri)
+			}
+		}
+	}
+	response, ok := s.call(ctx, result, schema.MethodResourcesRead, &schema.ReadResourceRequestParams{Uri: "mcptest://unknown"})
+	if ok && response.Error == nil {
+		result.errorf("%v: unknown resource must return an error", schema.MethodResourcesRead)
+	}
+}
+
+func (s *suite) checkPromptsGet(ctx context.Context, result *Result) {
+	count := 0
+	for _, prompt := range s.prompts {
+		if count >= s.options.readLimit {
+			break
+		}
+		if hasRequiredArgument(prompt) {
+			continue
+		}
+		count++
+		response, ok := s.call(ctx, result, schema.MethodPromptsGet, &schema.GetPromptRequestParams{Name: prompt.Name})
+		if !ok {
+			continue
+		}
+		getResult := &schema.GetPromptResult{}
+		if !expectResult(result, schema.MethodPromptsGet, response, getResult) {
+			continue
+		}
+		for _, message := range getResult.Messages {
+			if message.Role != schema.RoleUser && message.Role != schema.RoleAssistant {
+				result.errorf("%v: %q message role %q is invalid", schema.MethodPromptsGet, prompt.Name, message.Role)
+			}
+		}
+	}
+	for _, prompt := range s.prompts {
+		if !hasRequiredArgument(prompt) {
+			continue
+		}
+		count++
+		response, ok := s.call(ctx, result, schema.MethodPromptsGet, &schema.GetPromptRequestParams{Name: prompt.Name})
+		if ok && response.Error == nil {
+			result.errorf("%v: %q must reject missing required arguments", schema.MethodPromptsGet, prompt.Name)
+		}
+		break
+	}
+	if count == 0 {
+		result.skip("no prompts listed")
+	}
+}
+
+func hasRequiredArgument(prompt schema.Prompt) bool {
+	for _, argument := range prompt.Arguments {
+		if argument.Required != nil && *argument.Required {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *suite) checkSubscribe(ctx context.Context, result *Result) {
+	resources := s.capabilities.Resources
+	if resources == nil || resources.Subscribe == nil || !*resources.Subscribe {
+		result.skip("resources.subscribe capability not advertised")
+		return
+	}
+	if len(s.resources) == 0 {
+		result.skip("no resources listed")
+		return
+	}
+	uri := s.resources[0].Uri
+	response, ok := s.call(ctx, result, schema.MethodSubscribe, &schema.SubscribeRequestParams{Uri: uri})
+	if ok {
+		expectResult(result, schema.MethodSubscribe, response, &map[string]interface{}{})
+	}
+	response, ok = s.call(ctx, result, schema.MethodUnsubscribe, &schema.UnsubscribeRequestParams{Uri: uri})
+	if ok {
+		expectResult(result, schema.MethodUnsubscribe, response, &map[string]interface{}{})
+	}
+}
+
+func (s *suite) checkLoggingSetLevel(ctx context.Context, result *Result) {
+	if s.capabilities.Logging == nil {
+		result.skip("logging capability not advertised")
+		return
+	}
+	for _, level := range []schema.LoggingLevel{schema.LoggingLevelDebug, schema.LoggingLevelError} {
+		response, ok := s.call(ctx, result, schema.MethodLoggingSetLevel, &schema.SetLevelRequestParams{Level: level})
+		if ok {
+			expectResult(result, schema.MethodLoggingSetLevel, response, &map[string]interface{}{})
+		}
+	}
+}
+
+func (s *suite) checkCancellation(ctx context.Context, result *Result) {
+	call := s.options.cancellation
+	if call == nil {
+		result.skip("no cancellable tool configured, use WithCancellation")
+		return
+	}
+	request, err := s.conn.NewRequest(schema.MethodToolsCall, &schema.CallToolRequestParams{Name: call.Name, Arguments: call.Arguments})
+	if err != nil {
+		result.errorf("%v: %v", schema.MethodToolsCall, err)
+		return
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = s.conn.Send(ctx, request)
+	}()
+	time.Sleep(s.options.cancelAfter)
+	requestId := schema.RequestId(request.Id.(int))
+	reason := defaultCancelledReason
+	if err = s.conn.Notify(ctx, schema.MethodNotificationCanceled, &schema.CancelledNotificationParams{RequestId: &requestId, Reason: &reason}); err != nil {
+		result.errorf("%v: %v", schema.MethodNotificationCanceled, err)
+		return
+	}
+	select {
+	case <-done:
+	case <-time.After(s.options.timeout):
+		result.errorf("cancelled request %v did not finish within %v", request.Id, s.options.timeout)
+		return
+	}
+	response, ok := s.call(ctx, result, schema.MethodPing, &schema.PingRequestParams{})
+	if ok && response.Error != nil {
+		result.errorf("server is not responsive after cancellation: %v", response.Error.Message)
+	}
+}
+
+func (s *suite) checkProgress(ctx context.Context, result *Result) {
+	call := s.options.progress
+	if call == nil {
+		result.skip("no progress tool configured, use WithProgress")
+		return
+	}
+	params := map[string]interface{}{
+		"name":      call.Name,
+		"arguments": call.Arguments,
+		"_meta":     map[string]interface{}{"progressToken": progressToken},
+	}
+	response, ok := s.call(ctx, result, schema.MethodToolsCall, params)
+	if !ok {
+		return
+	}
+	if !expectResult(result, schema.MethodToolsCall, response, &map[string]interface{}{}) {
+		return
+	}
+	var notifications []*jsonrpc.Notification
+	deadline := time.Now().Add(s.options.timeout)
+	for {
+		notifications = s.conn.Notifications(schema.MethodNotificationProgress)
+		if len(notifications) > 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(progressWait)
+	}
+	if len(notifications) == 0 {
+		result.errorf("no %v received for progressToken %v", schema.MethodNotificationProgress, progressToken)
+		return
+	}
+	last := -1.0
+	for _, notification := range notifications {
+		progress := &schema.ProgressNotificationParams{}
+		if err := json.Unmarshal(notification.Params, progress); err != nil {
+			result.errorf("%v: invalid params: %v", schema.MethodNotificationProgress, err)
+			continue
+		}
+		if progress.ProgressToken != progressToken {
+			result.errorf("%v: unexpected progressToken %v", schema.MethodNotificationProgress, progress.ProgressToken)
+		}
+		if progress.Progress <= last {
+			result.errorf("%v: progress must increase, got %v after %v", schema.MethodNotificationProgress, progress.Progress, last)
+		}
+		if progress.Total != nil && progress.Progress > *progress.Total {
+			result.errorf("%v: progress %v exceeds total %v", schema.MethodNotificationProgress, progress.Progress, *progress.Total)
+		}
+		last = progress.Progress
+	}
+}
+
+func requestKey(id jsonrpc.RequestId) string {
+	data, _ := json.Marshal(id)
+	return string(data)
+}
@@ -0,0 +1,86 @@
+package mcptest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+)
+
+func TestCheck(t *testing.T) {
+	newHandler := func(inputType string) serverproto.NewHandler {
+		return serverproto.WithDefaultHandler(context.Background(), func(server *serverproto.DefaultHandler) error {
+			text := func(value string) *schema.CallToolResult {
+				return &schema.CallToolResult{Content: []schema.CallToolResultContentElem{schema.TextContent{Type: "text", Text: value}}}
+			}
+			server.RegisterToolWithSchema("greet", "greets", schema.ToolInputSchema{Type: inputType}, nil,
+				func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+					return text("hello"), nil
+				})
+			server.RegisterToolWithSchema("wait", "waits until cancelled", schema.ToolInputSchema{Type: "object"}, nil,
+				func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+					select {
+					case <-ctx.Done():
+						return nil, jsonrpc.NewInternalError("cancelled", nil)
+					case <-time.After(time.Minute):
+						return text("done"), nil
+					}
+				})
+			server.RegisterToolWithSchema("count", "reports progress", schema.ToolInputSchema{Type: "object"}, nil,
+				func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+					token, _ := ctx.Value(schema.TokenProgressContextKey).(schema.ProgressToken)
+					total := 3.0
+					for i := 1; i <= 3; i++ {
+						notification, _ := jsonrpc.NewNotification(schema.MethodNotificationProgress, &schema.ProgressNotificationParams{ProgressToken: token, Progress: float64(i), Total: &total})
+						_ = server.Notifier.Notify(ctx, notification)
+					}
+					return text("counted"), nil
+				})
+			server.RegisterResource(schema.Resource{Name: "hello", Uri: "/hello"},
+				func(ctx context.Context, request *schema.ReadResourceRequest) (*schema.ReadResourceResult, *jsonrpc.Error) {
+					return &schema.ReadResourceResult{Contents: []schema.ReadResourceResultContentsElem{{Uri: request.Params.Uri, Text: "hello"}}}, nil
+				})
+			required := true
+			server.RegisterPrompts(&schema.Prompt{Name: "welcome", Arguments: []schema.PromptArgument{{Name: "name", Required: &required}}},
+				func(ctx context.Context, params *schema.GetPromptRequestParams) (*schema.GetPromptResult, *jsonrpc.Error) {
+					return &schema.GetPromptResult{Messages: []schema.PromptMessage{{Role: schema.RoleUser, Content: schema.TextContent{Type: "text", Text: "hi " + params.Arguments["name"]}}}}, nil
+				})
+			return nil
+		})
+	}
+
+	testCases := []struct {
+		name         string
+		inputType    string
+		expectFailed []string
+	}{
+		{name: "conformant server", inputType: "object"},
+		{name: "invalid input schema", inputType: "string", expectFailed: []string{CheckToolsList}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			report, err := Check(context.Background(), Handler(newHandler(testCase.inputType)),
+				WithToolCall("greet", nil),
+				WithCancellation("wait", nil, 20*time.Millisecond),
+				WithProgress("count", nil),
+				WithTimeout(2*time.Second))
+			if !assert.NoError(t, err) {
+				return
+			}
+			var failed []string
+			for _, result := range report.Results {
+				if len(result.Failures) > 0 {
+					failed = append(failed, result.Name)
+				}
+			}
+			assert.ElementsMatch(t, testCase.expectFailed, failed, report.String())
+			assert.True(t, report.Result(CheckToolsCall).Passed())
+			assert.True(t, report.Result(CheckCancellation).Passed())
+			assert.True(t, report.Result(CheckProgress).Passed())
+		})
+	}
+}
@@ -34,6 +34,10 @@ type ServerTransportOptions struct {
 	Endpoint        string       `yaml:"endpoint" json:"endpoint"`
 	MessageEndpoint string       `yaml:"messageEndpoint" json:"messageEndpoint"`
 	Cors            *server.Cors `yaml:"cors" json:"cors"`
+	// RouteCors overrides the CORS policy per mounted route path
+	RouteCors map[string]*server.Cors `yaml:"routeCors" json:"routeCors"`
+	// AllowedHosts restricts the Host header (DNS rebinding protection); defaults to loopback names on loopback binds
+	AllowedHosts []string `yaml:"allowedHosts" json:"allowedHosts"`
 	// Optional HTTP transport configuration
 	SSEURI        string `yaml:"sseURI" json:"sseURI"`
 	SSEMessageURI string `yaml:"sseMessageURI" json:"sseMessageURI"`
@@ -111,6 +115,12 @@ func NewServer(newHandler protoserver.NewHandler, options *ServerOptions) (*serv
 				if transportOptions.Options.Cors != nil {
 					serverOptions = append(serverOptions, server.WithCORS(transportOptions.Options.Cors))
 				}
+				for path, cors := range transportOptions.Options.RouteCors {
+					serverOptions = append(serverOptions, server.WithRouteCORS(path, cors))
+				}
+				if len(transportOptions.Options.AllowedHosts) > 0 {
+					serverOptions = append(serverOptions, server.WithAllowedHosts(transportOptions.Options.AllowedHosts...))
+				}
 
 				// HTTP transport URIs and root redirect
 				if transportOptions.Options.SSEURI != "" {
@@ -143,6 +153,7 @@ func NewServer(newHandler protoserver.NewHandler, options *ServerOptions) (*serv
 					if authOptions.JRPCAuthorizer == nil && authOptions.UseJRPCAuthorizer {
 						authOptions.JRPCAuthorizer = authService.EnsureAuthorized
 					}
+					serverOptions = append(serverOptions, server.WithBFFAuthBinder(authService))
 				}
 
 				if authOptions.ProtectedResourcesHandler != nil {
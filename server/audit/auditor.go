@@ -0,0 +1,239 @@
+package audit
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/authorization"
+	"github.com/viant/mcp-protocol/schema"
+	"github.com/viant/mcp/internal/sessionid"
+	"github.com/viant/mcp/server/namespace"
+)
+
+// Auditor builds audit records for JSON-RPC calls and writes them to a Sink.
+type Auditor struct {
+	sink             Sink
+	redactor         *Redactor
+	provider         namespace.Provider
+	verifier         namespace.ClaimsVerifier
+	digestKey        []byte
+	methods          map[string]bool
+	includeArguments bool
+	onError          func(err error)
+}
+
+// Option customizes Auditor.
+type Option func(a *Auditor)
+
+// WithRedactionRules replaces the default redaction rules.
+func WithRedactionRules(rules ...Rule) Option {
+	return func(a *Auditor) {
+		a.redactor = NewRedactor(rules...)
+	}
+}
+
+// WithClaimsVerifier sets the verifier used to resolve the principal; without it, or when verification fails,
+// the principal is read from unverified claims and the record is marked with PrincipalUnverified.
+func WithClaimsVerifier(verifier namespace.ClaimsVerifier) Option {
+	return func(a *Auditor) {
+		a.verifier = verifier
+	}
+}
+
+// WithDigestKey makes ArgumentsDigest an HMAC-SHA256 keyed with key instead of a SHA-256 of the redacted arguments.
+func WithDigestKey(key []byte) Option {
+	return func(a *Auditor) {
+		a.digestKey = key
+	}
+}
+
+// WithArguments controls whether redacted arguments are recorded in addition to their digest (default true).
+func WithArguments(flag bool) Option {
+	return func(a *Auditor) {
+		a.includeArguments = flag
+	}
+}
+
+// WithMethods sets the audited JSON-RPC methods (default tools/call and resources/read).
+func WithMethods(methods ...string) Option {
+	return func(a *Auditor) {
+		a.methods = make(map[string]bool, len(methods))
+		for _, method := range methods {
+			a.methods[method] = true
+		}
+	}
+}
+
+// WithNamespaceProvider sets the provider used to resolve the caller namespace.
+func WithNamespaceProvider(provider namespace.Provider) Option {
+	return func(a *Auditor) {
+		a.provider = provider
+	}
+}
+
+// WithErrorHandler sets a callback for sink write failures (default prints to stderr).
+func WithErrorHandler(fn func(err error)) Option {
+	return func(a *Auditor) {
+		a.onError = fn
+	}
+}
+
+// New creates an Auditor writing to sink.
+func New(sink Sink, options ...Option) *Auditor {
+	ret := &Auditor{
+		sink:             sink,
+		redactor:         NewRedactor(DefaultRules()...),
+		provider:         namespace.NewProvider(&namespace.Config{PreferIdentity: true}),
+		methods:          map[string]bool{schema.MethodToolsCall: true, schema.MethodResourcesRead: true},
+		includeArguments: true,
+		onError: func(err error) {
+			fmt.Fprintf(os.Stderr, "[mcp/audit] %v\n", err)
+		},
+	}
+	for _, option := range options {
+		option(ret)
+	}
+	return ret
+}
+
+// Audits reports whether the method is audited.
+func (a *Auditor) Audits(method string) bool {
+	return a.methods[method]
+}
+
+// Record builds and writes an audit record for a completed request.
+func (a *Auditor) Record(ctx context.Context, request *jsonrpc.Request, response *jsonrpc.Response, started time.Time) {
+	record := &Record{
+		Time:       started.UTC(),
+		Session:    sessionid.FromContext(ctx),
+		Method:     request.Method,
+		RequestId:  request.Id,
+		DurationMs: float64(time.Since(started).Microseconds()) / 1000.0,
+	}
+	a.describePrincipal(ctx, record)
+	a.describeParams(request, record)
+	describeOutcome(response, record)
+	if err := a.sink.Write(ctx, record); err != nil && a.onError != nil {
+		a.onError(err)
+	}
+}
+
+// Close closes the underlying sink.
+func (a *Auditor) Close() error {
+	return a.sink.Close()
+}
+
+func (a *Auditor) describePrincipal(ctx context.Context, record *Record) {
+	if a.provider != nil {
+		if descriptor, err := a.provider.Namespace(ctx); err == nil {
+			record.Namespace = descriptor.Name
+		}
+	}
+	token := ""
+	if value, ok := ctx.Value(authorization.TokenKey).(*authorization.Token); ok && value != nil {
+		token = value.Token
+	}
+	if token == "" {
+		return
+	}
+	if len(token) > 7 && (token[:7] == "Bearer " || token[:7] == "bearer ") {
+		token = token[7:]
+	}
+	if a.verifier != nil {
+		if claims, err := a.verifier.VerifyClaims(ctx, token); err == nil {
+			record.Principal = selectPrincipal(map[string]any{"email": claims.Email(), "sub": claims.Subject()})
+			if record.Principal != "" {
+				return
+			}
+		}
+	}
+	var claims jwt.MapClaims
+	if _, _, err := new(jwt.Parser).ParseUnverified(token, &claims); err != nil {
+		return
+	}
+	if record.Principal = selectPrincipal(claims); record.Principal != "" {
+		record.PrincipalUnverified = true
+	}
+}
+
+func selectPrincipal(claims map[string]any) string {
+	for _, key := range []string{"email", "sub"} {
+		if value, ok := claims[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func (a *Auditor) describeParams(request *jsonrpc.Request, record *Record) {
+	switch request.Method {
+	case schema.MethodToolsCall:
+		params := &schema.CallToolRequestParams{}
+		if err := json.Unmarshal(request.Params, params); err != nil {
+			return
+		}
+		record.Tool = params.Name
+		if len(params.Arguments) == 0 {
+			return
+		}
+		redacted := a.redactor.Redact(params.Arguments)
+		record.ArgumentsDigest = a.digest(redacted)
+		if a.includeArguments {
+			record.Arguments = redacted
+		}
+	case schema.MethodResourcesRead, schema.MethodSubscribe, schema.MethodUnsubscribe:
+		params := &struct {
+			Uri string `json:"uri"`
+		}{}
+		if err := json.Unmarshal(request.Params, params); err == nil {
+			record.URI = params.Uri
+		}
+	case schema.MethodPromptsGet:
+		params := &schema.GetPromptRequestParams{}
+		if err := json.Unmarshal(request.Params, params); err == nil {
+			record.Prompt = params.Name
+		}
+	}
+}
+
+func describeOutcome(response *jsonrpc.Response, record *Record) {
+	record.Outcome = OutcomeSuccess
+	if response.Error != nil {
+		record.Outcome = OutcomeError
+		record.ErrorCode = response.Error.Code
+		record.ErrorMessage = response.Error.Message
+		return
+	}
+	if record.Method != schema.MethodToolsCall || len(response.Result) == 0 {
+		return
+	}
+	result := &struct {
+		IsError *bool `json:"isError"`
+	}{}
+	if err := json.Unmarshal(response.Result, result); err == nil && result.IsError != nil && *result.IsError {
+		record.Outcome = OutcomeToolError
+	}
+}
+
+// digest returns the digest of the redacted arguments; redaction keeps low-entropy secrets out of a plain hash.
+func (a *Auditor) digest(arguments map[string]interface{}) string {
+	data, err := json.Marshal(arguments)
+	if err != nil {
+		return ""
+	}
+	if len(a.digestKey) > 0 {
+		mac := hmac.New(sha256.New, a.digestKey)
+		mac.Write(data)
+		return "hmac-sha256:" + hex.EncodeToString(mac.Sum(nil))
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
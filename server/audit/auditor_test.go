@@ -0,0 +1,192 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/authorization"
+	"github.com/viant/mcp-protocol/schema"
+	"github.com/viant/mcp/server/namespace"
+)
+
+func TestAuditor_Record(t *testing.T) {
+	testCases := []struct {
+		name          string
+		params        *schema.CallToolRequestParams
+		response      *jsonrpc.Response
+		expectOutcome Outcome
+		expectArgs    map[string]interface{}
+	}{
+		{
+			name:          "success with redacted secrets",
+			params:        &schema.CallToolRequestParams{Name: "deploy", Arguments: map[string]interface{}{"env": "prod", "apiKey": "k1", "auth": map[string]interface{}{"password": "p"}}},
+			response:      &jsonrpc.Response{Result: []byte(`{"content":[]}`)},
+			expectOutcome: OutcomeSuccess,
+			expectArgs:    map[string]interface{}{"env": "prod", "apiKey": Redacted, "auth": map[string]interface{}{"password": Redacted}},
+		},
+		{
+			name:          "tool error",
+			params:        &schema.CallToolRequestParams{Name: "deploy"},
+			response:      &jsonrpc.Response{Result: []byte(`{"content":[],"isError":true}`)},
+			expectOutcome: OutcomeToolError,
+		},
+		{
+			name:          "rpc error",
+			params:        &schema.CallToolRequestParams{Name: "deploy"},
+			response:      &jsonrpc.Response{Error: jsonrpc.NewInvalidParamsError("bad", nil)},
+			expectOutcome: OutcomeError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var records []*Record
+			auditor := New(SinkFunc(func(ctx context.Context, record *Record) error {
+				records = append(records, record)
+				return nil
+			}))
+			request, err := jsonrpc.NewRequest(schema.MethodToolsCall, testCase.params)
+			if !assert.NoError(t, err) {
+				return
+			}
+			auditor.Record(context.Background(), request, testCase.response, time.Now())
+			if !assert.Len(t, records, 1) {
+				return
+			}
+			assert.Equal(t, "deploy", records[0].Tool)
+			assert.Equal(t, testCase.expectOutcome, records[0].Outcome)
+			assert.Equal(t, testCase.expectArgs, records[0].Arguments)
+			if len(testCase.params.Arguments) > 0 {
+				assert.NotEmpty(t, records[0].ArgumentsDigest)
+			}
+		})
+	}
+}
+
+func TestFileSink_Rotate(t *testing.T) {
+	location := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := NewFileSink(location, WithMaxSize(200), WithMaxBackups(2))
+	if !assert.NoError(t, err) {
+		return
+	}
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, sink.Write(context.Background(), &Record{Method: schema.MethodToolsCall, Tool: "tool", Outcome: OutcomeSuccess}))
+	}
+	assert.NoError(t, sink.Close())
+
+	for _, name := range []string{location, location + ".1", location + ".2"} {
+		file, err := os.Open(name)
+		if !assert.NoError(t, err, name) {
+			continue
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			record := &Record{}
+			assert.NoError(t, json.Unmarshal(scanner.Bytes(), record))
+			assert.Equal(t, "tool", record.Tool)
+		}
+		_ = file.Close()
+	}
+	_, err = os.Stat(location + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
+
+type hmacVerifier []byte
+
+type verifiedClaims jwt.MapClaims
+
+func (c verifiedClaims) Email() string       { value, _ := c["email"].(string); return value }
+func (c verifiedClaims) Subject() string     { value, _ := c["sub"].(string); return value }
+func (c verifiedClaims) Map() map[string]any { return c }
+
+func (v hmacVerifier) VerifyClaims(ctx context.Context, token string) (namespace.Claims, error) {
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) { return []byte(v), nil }); err != nil {
+		return nil, err
+	}
+	return verifiedClaims(claims), nil
+}
+
+func TestAuditor_Principal(t *testing.T) {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "u1"}).SignedString([]byte("secret"))
+	assert.NoError(t, err)
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "admin"}).SignedString([]byte("forged"))
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name             string
+		token            string
+		options          []Option
+		expectPrincipal  string
+		expectUnverified bool
+	}{
+		{name: "verified", token: token, options: []Option{WithClaimsVerifier(hmacVerifier("secret"))}, expectPrincipal: "u1"},
+		{name: "forged", token: forged, options: []Option{WithClaimsVerifier(hmacVerifier("secret"))}, expectPrincipal: "admin", expectUnverified: true},
+		{name: "no verifier", token: token, expectPrincipal: "u1", expectUnverified: true},
+		{name: "anonymous"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var records []*Record
+			auditor := New(SinkFunc(func(ctx context.Context, record *Record) error {
+				records = append(records, record)
+				return nil
+			}), testCase.options...)
+			request, err := jsonrpc.NewRequest(schema.MethodToolsCall, &schema.CallToolRequestParams{Name: "deploy"})
+			if !assert.NoError(t, err) {
+				return
+			}
+			ctx := context.Background()
+			if testCase.token != "" {
+				ctx = context.WithValue(ctx, authorization.TokenKey, &authorization.Token{Token: testCase.token})
+			}
+			auditor.Record(ctx, request, &jsonrpc.Response{Result: []byte(`{"content":[]}`)}, time.Now())
+			if !assert.Len(t, records, 1) {
+				return
+			}
+			assert.Equal(t, testCase.expectPrincipal, records[0].Principal)
+			assert.Equal(t, testCase.expectUnverified, records[0].PrincipalUnverified)
+		})
+	}
+}
+
+func TestAuditor_ArgumentsDigest(t *testing.T) {
+	testCases := []struct {
+		name         string
+		options      []Option
+		expectPrefix string
+	}{
+		{name: "sha256 of redacted arguments", expectPrefix: "sha256:"},
+		{name: "hmac", options: []Option{WithDigestKey([]byte("key"))}, expectPrefix: "hmac-sha256:"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var digests []string
+			auditor := New(SinkFunc(func(ctx context.Context, record *Record) error {
+				digests = append(digests, record.ArgumentsDigest)
+				return nil
+			}), testCase.options...)
+			for _, password := range []string{"1234", "9876"} {
+				request, err := jsonrpc.NewRequest(schema.MethodToolsCall, &schema.CallToolRequestParams{Name: "unlock", Arguments: map[string]interface{}{"door": "a", "password": password}})
+				if !assert.NoError(t, err) {
+					return
+				}
+				auditor.Record(context.Background(), request, &jsonrpc.Response{Result: []byte(`{"content":[]}`)}, time.Now())
+			}
+			if !assert.Len(t, digests, 2) {
+				return
+			}
+			assert.True(t, strings.HasPrefix(digests[0], testCase.expectPrefix), digests[0])
+			assert.Equal(t, digests[0], digests[1], "secrets do not affect the digest")
+		})
+	}
+}
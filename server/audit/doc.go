@@ -0,0 +1,8 @@
+// Package audit records who invoked which tool or read which resource.
+//
+// An Auditor is attached to a server with server.WithAuditor; it is invoked by
+// Handler.Serve for every audited method and writes an append-only Record
+// (timestamp, session, principal/namespace, method, tool or URI, argument
+// digest of the redacted arguments, outcome and duration) to a pluggable Sink.
+// FileSink is the default sink; it writes JSON lines and rotates files by size.
+package audit
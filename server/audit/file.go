@@ -0,0 +1,134 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	defaultMaxSize    = 100 * 1024 * 1024
+	defaultMaxBackups = 5
+)
+
+// FileSink appends records as JSON lines to a file and rotates it once it exceeds MaxSize.
+// Rotated files are renamed to <path>.1 ... <path>.<MaxBackups>, the oldest being discarded.
+type FileSink struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+	mu         sync.Mutex
+	file       *os.File
+	size       int64
+}
+
+// FileOption customizes FileSink.
+type FileOption func(s *FileSink)
+
+// WithMaxSize sets the size in bytes that triggers rotation (default 100MB).
+func WithMaxSize(size int64) FileOption {
+	return func(s *FileSink) {
+		s.maxSize = size
+	}
+}
+
+// WithMaxBackups sets the number of rotated files to keep (default 5).
+func WithMaxBackups(count int) FileOption {
+	return func(s *FileSink) {
+		s.maxBackups = count
+	}
+}
+
+// NewFileSink opens (or creates) the audit file at path in append-only mode.
+func NewFileSink(path string, options ...FileOption) (*FileSink, error) {
+	ret := &FileSink{path: path, maxSize: defaultMaxSize, maxBackups: defaultMaxBackups}
+	for _, option := range options {
+		option(ret)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create audit directory: %w", err)
+	}
+	if err := ret.open(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Write appends the record as a single JSON line.
+func (s *FileSink) Write(_ context.Context, record *Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return fmt.Errorf("audit sink %v is closed", s.path)
+	}
+	if s.maxSize > 0 && s.size > 0 && s.size+int64(len(data)) > s.maxSize {
+		if err = s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(data)
+	s.size += int64(n)
+	return err
+}
+
+// Close closes the underlying file.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+func (s *FileSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+func (s *FileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	s.file = nil
+	if s.maxBackups <= 0 {
+		if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return s.open()
+	}
+	_ = os.Remove(s.backupName(s.maxBackups))
+	for i := s.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(s.backupName(i), s.backupName(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(s.path, s.backupName(1)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return s.open()
+}
+
+func (s *FileSink) backupName(index int) string {
+	return fmt.Sprintf("%s.%d", s.path, index)
+}
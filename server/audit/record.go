@@ -0,0 +1,36 @@
+package audit
+
+import "time"
+
+// Outcome describes how an audited call ended.
+type Outcome string
+
+const (
+	// OutcomeSuccess indicates the call completed without error.
+	OutcomeSuccess Outcome = "success"
+	// OutcomeToolError indicates the tool returned a result flagged with isError.
+	OutcomeToolError Outcome = "toolError"
+	// OutcomeError indicates the call failed with a JSON-RPC error.
+	OutcomeError Outcome = "error"
+)
+
+// Record represents a single audit entry.
+type Record struct {
+	Time      time.Time `json:"time"`
+	Session   string    `json:"session,omitempty"`
+	Principal string    `json:"principal,omitempty"`
+	// PrincipalUnverified is set when Principal comes from token claims that were not verified.
+	PrincipalUnverified bool                   `json:"principalUnverified,omitempty"`
+	Namespace           string                 `json:"namespace,omitempty"`
+	Method              string                 `json:"method"`
+	RequestId           interface{}            `json:"requestId,omitempty"`
+	Tool                string                 `json:"tool,omitempty"`
+	URI                 string                 `json:"uri,omitempty"`
+	Prompt              string                 `json:"prompt,omitempty"`
+	ArgumentsDigest     string                 `json:"argumentsDigest,omitempty"`
+	Arguments           map[string]interface{} `json:"arguments,omitempty"`
+	Outcome             Outcome                `json:"outcome"`
+	ErrorCode           int                    `json:"errorCode,omitempty"`
+	ErrorMessage        string                 `json:"errorMessage,omitempty"`
+	DurationMs          float64                `json:"durationMs"`
+}
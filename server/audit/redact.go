@@ -0,0 +1,103 @@
+package audit
+
+import (
+	"path"
+	"strings"
+)
+
+// Redacted replaces values of fields matched by a redaction rule.
+const Redacted = "[REDACTED]"
+
+// Rule matches argument fields to redact. Pattern is a case-insensitive glob
+// (see path.Match) evaluated against both the field name and its dotted path,
+// e.g. "password", "*token*" or "credentials.*".
+type Rule struct {
+	Pattern     string `json:"pattern" yaml:"pattern"`
+	Replacement string `json:"replacement,omitempty" yaml:"replacement,omitempty"`
+}
+
+// DefaultRules returns rules covering common secret field names.
+func DefaultRules() []Rule {
+	return []Rule{
+		{Pattern: "*password*"},
+		{Pattern: "*passwd*"},
+		{Pattern: "*secret*"},
+		{Pattern: "*token*"},
+		{Pattern: "*apikey*"},
+		{Pattern: "*api_key*"},
+		{Pattern: "authorization"},
+		{Pattern: "*credential*"},
+		{Pattern: "*private_key*"},
+	}
+}
+
+// Redactor removes secrets from tool arguments before they are recorded.
+type Redactor struct {
+	Rules []Rule
+}
+
+// NewRedactor creates a redactor with the supplied rules.
+func NewRedactor(rules ...Rule) *Redactor {
+	return &Redactor{Rules: rules}
+}
+
+// Redact returns a deep copy of args with matched fields replaced.
+func (r *Redactor) Redact(args map[string]interface{}) map[string]interface{} {
+	if args == nil {
+		return nil
+	}
+	return r.redactMap("", args)
+}
+
+func (r *Redactor) redactMap(prefix string, value map[string]interface{}) map[string]interface{} {
+	ret := make(map[string]interface{}, len(value))
+	for key, item := range value {
+		fieldPath := key
+		if prefix != "" {
+			fieldPath = prefix + "." + key
+		}
+		if replacement, ok := r.match(key, fieldPath); ok {
+			ret[key] = replacement
+			continue
+		}
+		ret[key] = r.redactValue(fieldPath, item)
+	}
+	return ret
+}
+
+func (r *Redactor) redactValue(fieldPath string, value interface{}) interface{} {
+	switch actual := value.(type) {
+	case map[string]interface{}:
+		return r.redactMap(fieldPath, actual)
+	case []interface{}:
+		ret := make([]interface{}, len(actual))
+		for i, item := range actual {
+			ret[i] = r.redactValue(fieldPath, item)
+		}
+		return ret
+	}
+	return value
+}
+
+func (r *Redactor) match(key, fieldPath string) (string, bool) {
+	if r == nil {
+		return "", false
+	}
+	key = strings.ToLower(key)
+	fieldPath = strings.ToLower(fieldPath)
+	for _, rule := range r.Rules {
+		pattern := strings.ToLower(rule.Pattern)
+		matched, _ := path.Match(pattern, key)
+		if !matched {
+			matched, _ = path.Match(pattern, fieldPath)
+		}
+		if !matched {
+			continue
+		}
+		if rule.Replacement != "" {
+			return rule.Replacement, true
+		}
+		return Redacted, true
+	}
+	return "", false
+}
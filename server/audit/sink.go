@@ -0,0 +1,22 @@
+package audit
+
+import "context"
+
+// Sink persists audit records; implementations must be safe for concurrent use.
+type Sink interface {
+	Write(ctx context.Context, record *Record) error
+	Close() error
+}
+
+// SinkFunc adapts a function to the Sink interface.
+type SinkFunc func(ctx context.Context, record *Record) error
+
+// Write calls f(ctx, record).
+func (f SinkFunc) Write(ctx context.Context, record *Record) error {
+	return f(ctx, record)
+}
+
+// Close is a no-op.
+func (f SinkFunc) Close() error {
+	return nil
+}
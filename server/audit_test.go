@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+	"github.com/viant/mcp/server/audit"
+)
+
+func TestServer_Audit(t *testing.T) {
+	var mu sync.Mutex
+	var records []*audit.Record
+	auditor := audit.New(audit.SinkFunc(func(ctx context.Context, record *audit.Record) error {
+		mu.Lock()
+		defer mu.Unlock()
+		records = append(records, record)
+		return nil
+	}))
+	newHandler := serverproto.WithDefaultHandler(context.Background(), func(server *serverproto.DefaultHandler) error {
+		server.RegisterToolWithSchema("echo", "echoes text", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				return &schema.CallToolResult{Content: []schema.CallToolResultContentElem{schema.TextContent{Type: "text", Text: "ok"}}}, nil
+			})
+		return nil
+	})
+	srv, err := New(WithNewHandler(newHandler), WithAuditor(auditor), WithLimits(&Limits{MaxParamsBytes: 64}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	ctx := context.Background()
+	handler := srv.newHandler(ctx, nil)
+
+	testCases := []struct {
+		name          string
+		version       string
+		method        string
+		params        interface{}
+		expectRecord  bool
+		expectOutcome audit.Outcome
+		expectCode    int
+	}{
+		{name: "tool call", method: schema.MethodToolsCall, params: map[string]interface{}{"name": "echo"}, expectRecord: true, expectOutcome: audit.OutcomeSuccess},
+		{name: "invalid version rejected", version: "1.0", method: schema.MethodToolsCall, params: map[string]interface{}{"name": "echo"}, expectRecord: true, expectOutcome: audit.OutcomeError, expectCode: jsonrpc.InvalidRequest},
+		{name: "method not found rejected", method: schema.MethodResourcesRead, params: map[string]interface{}{"uri": "file:///x"}, expectRecord: true, expectOutcome: audit.OutcomeError, expectCode: jsonrpc.MethodNotFound},
+		{name: "limit rejected", method: schema.MethodToolsCall, params: map[string]interface{}{"name": "echo", "arguments": map[string]interface{}{"text": strings.Repeat("x", 64)}}, expectRecord: true, expectOutcome: audit.OutcomeError, expectCode: jsonrpc.InvalidRequest},
+		{name: "not audited", method: schema.MethodPing},
+	}
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mu.Lock()
+			records = nil
+			mu.Unlock()
+			params, _ := json.Marshal(tc.params)
+			version := tc.version
+			if version == "" {
+				version = jsonrpc.Version
+			}
+			request := &jsonrpc.Request{Jsonrpc: version, Id: i + 1, Method: tc.method, Params: params}
+			handler.Serve(ctx, request, &jsonrpc.Response{Jsonrpc: jsonrpc.Version, Id: request.Id})
+			mu.Lock()
+			defer mu.Unlock()
+			if !tc.expectRecord {
+				assert.Empty(t, records)
+				return
+			}
+			if !assert.Len(t, records, 1) {
+				return
+			}
+			assert.EqualValues(t, tc.method, records[0].Method)
+			assert.EqualValues(t, tc.expectOutcome, records[0].Outcome)
+			assert.EqualValues(t, tc.expectCode, records[0].ErrorCode)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	streamauth "github.com/viant/jsonrpc/transport/server/auth"
+)
+
+// BFFCookie describes the cookie carrying the opaque BFF auth grant id.
+type BFFCookie struct {
+	Name     string        `yaml:"name,omitempty" json:"name,omitempty"`
+	Path     string        `yaml:"path,omitempty" json:"path,omitempty"`
+	Domain   string        `yaml:"domain,omitempty" json:"domain,omitempty"`
+	Secure   bool          `yaml:"secure,omitempty" json:"secure,omitempty"`
+	SameSite http.SameSite `yaml:"sameSite,omitempty" json:"sameSite,omitempty"`
+	// MaxAge in seconds; zero makes it a session cookie.
+	MaxAge int `yaml:"maxAge,omitempty" json:"maxAge,omitempty"`
+}
+
+// DefaultBFFCookie returns the default BFF auth cookie: HttpOnly, SameSite=Lax, scoped to "/".
+func DefaultBFFCookie() *BFFCookie {
+	return &BFFCookie{Name: defaultBFFAuthCookieName, Path: "/", SameSite: http.SameSiteLaxMode}
+}
+
+// Cookie returns an HttpOnly cookie with value; unset name and path use the defaults.
+func (c *BFFCookie) Cookie(value string) *http.Cookie {
+	ret := &http.Cookie{Name: c.name(), Value: value, Path: c.Path, Domain: c.Domain, Secure: c.Secure, HttpOnly: true, SameSite: c.SameSite, MaxAge: c.MaxAge}
+	if ret.Path == "" {
+		ret.Path = "/"
+	}
+	return ret
+}
+
+func (c *BFFCookie) name() string {
+	if c.Name == "" {
+		return defaultBFFAuthCookieName
+	}
+	return c.Name
+}
+
+// BindBFFAuth makes the service mint and touch grants in store with cookie, typically those of the
+// server transports; nil arguments keep the current settings.
+func (s *Service) BindBFFAuth(store streamauth.Store, cookie *BFFCookie) {
+	if store != nil {
+		s.bffGrantStore = store
+	}
+	if cookie != nil {
+		s.bffCookie = cookie
+	}
+}
+
+type bffAuthKey string
+
+const bffAuthContextKey bffAuthKey = "bff-auth"
+
+// bffAuthBinding holds the grant store and cookie settings of the server handling a request.
+type bffAuthBinding struct {
+	store  streamauth.Store
+	cookie *BFFCookie
+}
+
+// WithBFFAuthContext returns ctx carrying the server grant store and cookie; a service not bound with
+// BindBFFAuth mints and touches grants with them. Servers set it around their HTTP authorizer.
+func WithBFFAuthContext(ctx context.Context, store streamauth.Store, cookie *BFFCookie) context.Context {
+	return context.WithValue(ctx, bffAuthContextKey, &bffAuthBinding{store: store, cookie: cookie})
+}
+
+func bffAuthFromContext(ctx context.Context) *bffAuthBinding {
+	binding, _ := ctx.Value(bffAuthContextKey).(*bffAuthBinding)
+	return binding
+}
+
+var defaultBFFGrantStoreOnce sync.Once
+
+// grantStore returns the bound store, the store of the server handling r, or the package default store.
+func (s *Service) grantStore(r *http.Request) streamauth.Store {
+	if s.bffGrantStore != nil {
+		return s.bffGrantStore
+	}
+	if binding := bffAuthFromContext(r.Context()); binding != nil && binding.store != nil {
+		return binding.store
+	}
+	defaultBFFGrantStoreOnce.Do(func() {
+		if defaultBFFGrantStore == nil {
+			defaultBFFGrantStore = streamauth.NewMemoryStore(30*time.Minute, 24*time.Hour, 2*time.Minute)
+		}
+	})
+	return defaultBFFGrantStore
+}
+
+// bffAuthCookie returns the bound cookie settings, those of the server handling r, or the package default.
+func (s *Service) bffAuthCookie(r *http.Request) *BFFCookie {
+	if s.bffCookie != nil {
+		return s.bffCookie
+	}
+	if binding := bffAuthFromContext(r.Context()); binding != nil && binding.cookie != nil {
+		return binding.cookie
+	}
+	if defaultBFFAuthCookie != nil {
+		return defaultBFFAuthCookie
+	}
+	return DefaultBFFCookie()
+}
@@ -6,17 +6,26 @@ import streamauth "github.com/viant/jsonrpc/transport/server/auth"
 var (
 	defaultBFFGrantStore     streamauth.Store
 	defaultBFFAuthCookieName = "BFF-Auth-Session"
+	defaultBFFAuthCookie     *BFFCookie
 )
 
-// SetDefaultBFFAuthStore sets the shared auth grant store used by the auth middleware
-// to mint or touch BFF auth cookies. Typically provided by the jsonrpc transport setup.
+// SetDefaultBFFAuthStore sets the shared auth grant store used by the auth middleware to mint or touch
+// BFF auth cookies outside of a server authorizer when the service is not bound via BindBFFAuth;
+// call it before serving.
 func SetDefaultBFFAuthStore(store streamauth.Store) {
 	defaultBFFGrantStore = store
 }
 
-// SetDefaultBFFAuthCookieName sets the default cookie name for the BFF auth session id.
+// SetDefaultBFFAuthCookieName sets the default cookie name for the BFF auth session id, used when
+// the service is not bound to server cookie settings via BindBFFAuth.
 func SetDefaultBFFAuthCookieName(name string) {
 	if name != "" {
 		defaultBFFAuthCookieName = name
 	}
 }
+
+// SetDefaultBFFAuthCookie sets the cookie settings used by the auth middleware outside of a server authorizer
+// when the service is not bound via BindBFFAuth; nil restores DefaultBFFCookie.
+func SetDefaultBFFAuthCookie(cookie *BFFCookie) {
+	defaultBFFAuthCookie = cookie
+}
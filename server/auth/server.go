@@ -34,8 +34,8 @@ type Service struct {
 
 	// bffGrantStore holds references to the jsonrpc BFF auth store (shared with transport
 	// handlers) to allow setting an auth cookie when authorization has been established.
-	bffGrantStore     streamauth.Store
-	bffAuthCookieName string
+	bffGrantStore streamauth.Store
+	bffCookie     *BFFCookie
 }
 
 func (s *Service) RegisterHandlers(mux *http.ServeMux) {
@@ -109,10 +109,6 @@ func (s *Service) resolveAuthorizationRule(jRequest *jsonrpc.Request) (*authoriz
 func (s *Service) handleAuthorization(w http.ResponseWriter, r *http.Request, next http.Handler, resourceURI string, rule *authorization.Authorization) {
 	err := s.ensureResourceToken(r, rule)
 	authHeader := strings.TrimSpace(r.Header.Get("Authorization"))
-	cookieName := s.bffAuthCookieName
-	if cookieName == "" {
-		cookieName = defaultBFFAuthCookieName
-	}
 	if strings.HasPrefix(strings.ToLower(authHeader), "bearer ") {
 		token := &authorization.Token{Token: authHeader}
 		rWithToken := r.WithContext(context.WithValue(r.Context(), authorization.TokenKey, token))
@@ -165,32 +161,21 @@ func (s *Service) handleAuthorization(w http.ResponseWriter, r *http.Request, ne
 // is established and a shared grant store is available. The cookie contains an
 // opaque grant id; tokens are never stored in cookies.
 func (s *Service) ensureBFFAuthCookie(w http.ResponseWriter, r *http.Request) {
-	if s.bffGrantStore == nil {
-		if defaultBFFGrantStore == nil {
-			defaultBFFGrantStore = streamauth.NewMemoryStore(30*time.Minute, 24*time.Hour, 2*time.Minute)
-		}
-		s.bffGrantStore = defaultBFFGrantStore
-	}
 	if r.Method != http.MethodPost {
 		return
 	}
-	name := s.bffAuthCookieName
-	if s.Config != nil && s.Config.BackendForFrontend != nil && strings.TrimSpace(s.Config.BackendForFrontend.AuthorizationExchangeHeader) != "" {
-		// Name remains default unless overridden globally; header presence only indicates BFF mode
-	}
-	if name == "" {
-		name = defaultBFFAuthCookieName
-	}
-	if ck, err := r.Cookie(name); err == nil && ck.Value != "" {
+	store := s.grantStore(r)
+	cookie := s.bffAuthCookie(r)
+	if ck, err := r.Cookie(cookie.name()); err == nil && ck.Value != "" {
 		// touch existing grant and refresh cookie
-		_ = s.bffGrantStore.Touch(r.Context(), ck.Value, time.Now())
-		http.SetCookie(w, &http.Cookie{Name: name, Value: ck.Value, Path: "/", HttpOnly: true, SameSite: http.SameSiteLaxMode})
+		_ = store.Touch(r.Context(), ck.Value, time.Now())
+		http.SetCookie(w, cookie.Cookie(ck.Value))
 		return
 	}
 	// mint new grant and set cookie
 	g := streamauth.NewGrant("")
-	_ = s.bffGrantStore.Put(r.Context(), g)
-	http.SetCookie(w, &http.Cookie{Name: name, Value: g.ID, Path: "/", HttpOnly: true, SameSite: http.SameSiteLaxMode})
+	_ = store.Put(r.Context(), g)
+	http.SetCookie(w, cookie.Cookie(g.ID))
 }
 
 var aDbg struct {
@@ -209,11 +194,7 @@ func (s *Service) getResourceKey(r *http.Request, rule *authorization.Authorizat
 	// Prefer a stable BFF auth cookie id when present so tokens survive
 	// across transport session reconnects. Fall back to current session id.
 	key := s.SessionIdProvider(r)
-	name := s.bffAuthCookieName
-	if name == "" {
-		name = defaultBFFAuthCookieName
-	}
-	if ck, err := r.Cookie(name); err == nil && ck != nil && ck.Value != "" {
+	if ck, err := r.Cookie(s.bffAuthCookie(r).name()); err == nil && ck != nil && ck.Value != "" {
 		key = ck.Value
 	}
 	return key + rule.ProtectedResourceMetadata.Resource
@@ -282,7 +263,5 @@ func New(config *Config) (*Service, error) {
 			}
 			return ""
 		},
-		bffGrantStore:     defaultBFFGrantStore,
-		bffAuthCookieName: defaultBFFAuthCookieName,
 	}, nil
 }
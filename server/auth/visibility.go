@@ -0,0 +1,235 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/viant/mcp-protocol/authorization"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+// VisibilityMode controls how list results are adjusted for callers lacking authorization.
+type VisibilityMode string
+
+const (
+	// VisibilityHide removes tools, resources and prompts the caller is not authorized to use.
+	VisibilityHide VisibilityMode = "hide"
+	// VisibilityMark keeps all entries but marks unauthorized ones in their _meta.
+	VisibilityMark VisibilityMode = "mark"
+)
+
+// AuthorizationMetaKey is the _meta key used to mark entries that require authorization.
+const AuthorizationMetaKey = "authorization"
+
+// ClaimsMatcher decides whether the caller claims satisfy an authorization rule.
+type ClaimsMatcher func(claims map[string]any, rule *authorization.Authorization) bool
+
+// TokenVerifier verifies the caller token (signature, issuer, expiry) and returns its claims.
+type TokenVerifier func(ctx context.Context, token string) (map[string]any, error)
+
+// Visibility filters list results per principal using the same Policy that protects calls.
+// Protected entries are only visible to callers whose token passes Verifier.
+type Visibility struct {
+	Policy   *authorization.Policy
+	Mode     VisibilityMode
+	Matcher  ClaimsMatcher
+	Verifier TokenVerifier
+}
+
+// VisibilityOption customizes Visibility.
+type VisibilityOption func(v *Visibility)
+
+// WithClaimsMatcher overrides the default required scopes matcher.
+func WithClaimsMatcher(matcher ClaimsMatcher) VisibilityOption {
+	return func(v *Visibility) {
+		v.Matcher = matcher
+	}
+}
+
+// WithTokenVerifier sets the verifier trusted for caller claims; without it protected entries are never visible.
+func WithTokenVerifier(verifier TokenVerifier) VisibilityOption {
+	return func(v *Visibility) {
+		v.Verifier = verifier
+	}
+}
+
+// NewVisibility creates a Visibility for the supplied policy; an empty mode defaults to VisibilityHide.
+func NewVisibility(policy *authorization.Policy, mode VisibilityMode, options ...VisibilityOption) *Visibility {
+	if mode == "" {
+		mode = VisibilityHide
+	}
+	ret := &Visibility{Policy: policy, Mode: mode, Matcher: MatchScopes}
+	for _, option := range options {
+		option(ret)
+	}
+	return ret
+}
+
+// Visibility returns a list Visibility driven by the service policy.
+func (s *Service) Visibility(mode VisibilityMode, options ...VisibilityOption) *Visibility {
+	return NewVisibility(s.Policy, mode, options...)
+}
+
+// ToolRule returns the authorization rule protecting the named tool, falling back to the global rule.
+func (v *Visibility) ToolRule(name string) *authorization.Authorization {
+	if v.Policy == nil {
+		return nil
+	}
+	if rule, ok := v.Policy.Tools[name]; ok {
+		return rule
+	}
+	return v.Policy.Global
+}
+
+// ResourceRule returns the authorization rule protecting the resource URI, falling back to the global rule.
+func (v *Visibility) ResourceRule(uri string) *authorization.Authorization {
+	if v.Policy == nil {
+		return nil
+	}
+	if rule, ok := v.Policy.Resources[uri]; ok {
+		return rule
+	}
+	return v.Policy.Global
+}
+
+// PromptRule returns the authorization rule protecting prompts; policies only define a global rule for prompts.
+func (v *Visibility) PromptRule(_ string) *authorization.Authorization {
+	if v.Policy == nil {
+		return nil
+	}
+	return v.Policy.Global
+}
+
+// Allowed reports whether the verified claims of the caller token in context satisfy the rule.
+func (v *Visibility) Allowed(ctx context.Context, rule *authorization.Authorization) bool {
+	if rule == nil {
+		return true
+	}
+	token := TokenFromContext(ctx)
+	if token == "" || v.Verifier == nil {
+		return false
+	}
+	claims, err := v.Verifier(ctx, token)
+	if err != nil {
+		return false
+	}
+	matcher := v.Matcher
+	if matcher == nil {
+		matcher = MatchScopes
+	}
+	return matcher(claims, rule)
+}
+
+// FilterTools applies the visibility mode to tools.
+func (v *Visibility) FilterTools(ctx context.Context, tools []schema.Tool) []schema.Tool {
+	ret := make([]schema.Tool, 0, len(tools))
+	for _, tool := range tools {
+		rule := v.ToolRule(tool.Name)
+		if v.Allowed(ctx, rule) {
+			ret = append(ret, tool)
+			continue
+		}
+		if v.Mode == VisibilityMark {
+			tool.Meta = markMeta(tool.Meta, rule)
+			ret = append(ret, tool)
+		}
+	}
+	return ret
+}
+
+// FilterResources applies the visibility mode to resources.
+func (v *Visibility) FilterResources(ctx context.Context, resources []schema.Resource) []schema.Resource {
+	ret := make([]schema.Resource, 0, len(resources))
+	for _, resource := range resources {
+		rule := v.ResourceRule(resource.Uri)
+		if v.Allowed(ctx, rule) {
+			ret = append(ret, resource)
+			continue
+		}
+		if v.Mode == VisibilityMark {
+			resource.Meta = markMeta(resource.Meta, rule)
+			ret = append(ret, resource)
+		}
+	}
+	return ret
+}
+
+// FilterPrompts applies the visibility mode to prompts.
+func (v *Visibility) FilterPrompts(ctx context.Context, prompts []schema.Prompt) []schema.Prompt {
+	ret := make([]schema.Prompt, 0, len(prompts))
+	for _, prompt := range prompts {
+		rule := v.PromptRule(prompt.Name)
+		if v.Allowed(ctx, rule) {
+			ret = append(ret, prompt)
+			continue
+		}
+		if v.Mode == VisibilityMark {
+			prompt.Meta = markMeta(prompt.Meta, rule)
+			ret = append(ret, prompt)
+		}
+	}
+	return ret
+}
+
+// MatchScopes is the default ClaimsMatcher; it requires every rule scope in the "scope" or "scp" claim.
+func MatchScopes(claims map[string]any, rule *authorization.Authorization) bool {
+	if len(rule.RequiredScopes) == 0 {
+		return true
+	}
+	granted := map[string]bool{}
+	for _, key := range []string{"scope", "scp"} {
+		switch actual := claims[key].(type) {
+		case string:
+			for _, scope := range strings.Fields(actual) {
+				granted[scope] = true
+			}
+		case []interface{}:
+			for _, item := range actual {
+				if scope, ok := item.(string); ok {
+					granted[scope] = true
+				}
+			}
+		}
+	}
+	for _, scope := range rule.RequiredScopes {
+		if !granted[scope] {
+			return false
+		}
+	}
+	return true
+}
+
+// TokenFromContext returns the raw bearer token stored under authorization.TokenKey.
+func TokenFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	var token string
+	switch actual := ctx.Value(authorization.TokenKey).(type) {
+	case *authorization.Token:
+		if actual != nil {
+			token = actual.Token
+		}
+	case string:
+		token = actual
+	}
+	token = strings.TrimSpace(token)
+	if strings.HasPrefix(strings.ToLower(token), "bearer ") {
+		token = strings.TrimSpace(token[len("bearer "):])
+	}
+	return token
+}
+
+// markMeta returns a copy of meta annotated with the authorization requirement.
+func markMeta(meta map[string]interface{}, rule *authorization.Authorization) map[string]interface{} {
+	ret := make(map[string]interface{}, len(meta)+1)
+	for k, v := range meta {
+		ret[k] = v
+	}
+	requirement := map[string]interface{}{"required": true}
+	if len(rule.RequiredScopes) > 0 {
+		requirement["requiredScopes"] = rule.RequiredScopes
+	}
+	ret[AuthorizationMetaKey] = requirement
+	return ret
+}
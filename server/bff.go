@@ -0,0 +1,69 @@
+package server
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	streamauth "github.com/viant/jsonrpc/transport/server/auth"
+	"github.com/viant/mcp/server/auth"
+)
+
+// BFFAuthBinder is implemented by HTTP authorizers minting BFF auth cookies, such as auth.Service, to share
+// the server grant store and cookie settings.
+type BFFAuthBinder interface {
+	BindBFFAuth(store streamauth.Store, cookie *auth.BFFCookie)
+}
+
+// bffAuth holds the per-server BFF auth grant store and cookie settings.
+type bffAuth struct {
+	store       streamauth.Store
+	idleTTL     time.Duration
+	maxTTL      time.Duration
+	rotateGrace time.Duration
+	cookie      *auth.BFFCookie
+	binders     []BFFAuthBinder
+	once        sync.Once
+}
+
+func defaultBFFAuth() *bffAuth {
+	return &bffAuth{
+		idleTTL:     30 * time.Minute,
+		maxTTL:      24 * time.Hour,
+		rotateGrace: 2 * time.Minute,
+		cookie:      auth.DefaultBFFCookie(),
+	}
+}
+
+// grantStore returns the configured store or an in-memory store created once per server.
+func (b *bffAuth) grantStore() streamauth.Store {
+	b.once.Do(func() {
+		if b.store == nil {
+			b.store = streamauth.NewMemoryStore(b.idleTTL, b.maxTTL, b.rotateGrace)
+		}
+	})
+	return b.store
+}
+
+// bind shares the grant store and cookie settings with the registered binders.
+func (b *bffAuth) bind() streamauth.Store {
+	store := b.grantStore()
+	for _, binder := range b.binders {
+		binder.BindBFFAuth(store, b.cookie)
+	}
+	return store
+}
+
+// authorizer wraps authorizer so that services not bound with a binder, such as auth.Service.Middleware,
+// mint and touch grants in the server store with the server cookie settings.
+func (b *bffAuth) authorizer(authorizer Middleware) Middleware {
+	if authorizer == nil {
+		return nil
+	}
+	return func(next http.Handler) http.Handler {
+		handler := authorizer(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler.ServeHTTP(w, r.WithContext(auth.WithBFFAuthContext(r.Context(), b.grantStore(), b.cookie)))
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	streamauth "github.com/viant/jsonrpc/transport/server/auth"
+	"github.com/viant/mcp-protocol/authorization"
+	"github.com/viant/mcp-protocol/oauth2/meta"
+	serverproto "github.com/viant/mcp-protocol/server"
+	"github.com/viant/mcp/server/auth"
+)
+
+type recordingBinder struct {
+	store  streamauth.Store
+	cookie *auth.BFFCookie
+}
+
+func (b *recordingBinder) BindBFFAuth(store streamauth.Store, cookie *auth.BFFCookie) {
+	b.store, b.cookie = store, cookie
+}
+
+func TestServer_BFFAuth(t *testing.T) {
+	newHandler := serverproto.WithDefaultHandler(context.Background())
+	customStore := streamauth.NewMemoryStore(time.Minute, time.Hour, time.Second)
+	secureCookie := &auth.BFFCookie{Name: "app-grant", Domain: "example.com", Secure: true, SameSite: http.SameSiteStrictMode}
+
+	testCases := []struct {
+		name         string
+		options      []Option
+		expectStore  streamauth.Store
+		expectCookie *auth.BFFCookie
+	}{
+		{name: "defaults", expectCookie: auth.DefaultBFFCookie()},
+		{name: "custom store and cookie", options: []Option{WithBFFAuthStore(customStore), WithBFFAuthCookie(secureCookie)}, expectStore: customStore, expectCookie: secureCookie},
+		{name: "custom ttl", options: []Option{WithBFFAuthTTL(time.Minute, time.Hour, 0)}, expectCookie: auth.DefaultBFFCookie()},
+	}
+	var stores []streamauth.Store
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			binder := &recordingBinder{}
+			srv, err := New(append([]Option{WithNewHandler(newHandler), WithBFFAuthBinder(binder)}, tc.options...)...)
+			if !assert.NoError(t, err) {
+				return
+			}
+			srv.HTTP(context.Background(), "")
+			if tc.expectStore != nil {
+				assert.Same(t, tc.expectStore, binder.store)
+			} else {
+				assert.NotNil(t, binder.store)
+			}
+			assert.EqualValues(t, tc.expectCookie, binder.cookie)
+			srv.HTTP(context.Background(), "")
+			assert.Same(t, binder.store, srv.bffAuth.grantStore(), "store is kept across HTTP calls")
+			stores = append(stores, binder.store)
+		})
+	}
+	if assert.Len(t, stores, 3) {
+		assert.NotSame(t, stores[0], stores[2], "servers do not share the default store")
+	}
+}
+
+func TestServer_BFFAuthAuthorizer(t *testing.T) {
+	newHandler := serverproto.WithDefaultHandler(context.Background())
+	policy := &authorization.Policy{Global: &authorization.Authorization{ProtectedResourceMetadata: &meta.ProtectedResourceMetadata{Resource: "https://example.com"}}}
+
+	testCases := []struct {
+		name  string
+		bound bool
+	}{
+		{name: "unbound authorizer uses the server store"},
+		{name: "bound authorizer", bound: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			authService, err := auth.New(&auth.Config{Policy: policy})
+			if !assert.NoError(t, err) {
+				return
+			}
+			// two servers sharing the service keep their grants and cookies apart
+			var servers []*Server
+			for _, name := range []string{"first", "second"} {
+				options := []Option{WithNewHandler(newHandler), WithAuthorizer(authService.Middleware), WithBFFAuthCookie(&auth.BFFCookie{Name: "grant-" + name})}
+				if tc.bound {
+					options = append(options, WithBFFAuthBinder(authService))
+				}
+				srv, err := New(options...)
+				if !assert.NoError(t, err) {
+					return
+				}
+				srv.HTTP(context.Background(), "")
+				servers = append(servers, srv)
+			}
+			if tc.bound { // the service is bound to the last server started
+				servers = servers[1:]
+			}
+			for _, srv := range servers {
+				request := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo"}}`))
+				request.Header.Set("Authorization", "Bearer token")
+				recorder := httptest.NewRecorder()
+				srv.authorizer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})).ServeHTTP(recorder, request)
+
+				cookies := recorder.Result().Cookies()
+				if !assert.Len(t, cookies, 1) {
+					return
+				}
+				assert.EqualValues(t, srv.bffAuth.cookie.Name, cookies[0].Name)
+				grant, err := srv.bffAuth.grantStore().Get(context.Background(), cookies[0].Value)
+				assert.NoError(t, err)
+				assert.NotNil(t, grant, "grant is minted in the server store")
+			}
+		})
+	}
+}
+
+func TestBFFCookie_Cookie(t *testing.T) {
+	testCases := []struct {
+		name   string
+		cookie *auth.BFFCookie
+		expect *http.Cookie
+	}{
+		{name: "defaults", cookie: &auth.BFFCookie{}, expect: &http.Cookie{Name: "BFF-Auth-Session", Value: "id", Path: "/", HttpOnly: true}},
+		{name: "attributes", cookie: &auth.BFFCookie{Name: "grant", Path: "/mcp", Domain: "example.com", Secure: true, SameSite: http.SameSiteNoneMode, MaxAge: 60},
+			expect: &http.Cookie{Name: "grant", Value: "id", Path: "/mcp", Domain: "example.com", Secure: true, HttpOnly: true, SameSite: http.SameSiteNoneMode, MaxAge: 60}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.EqualValues(t, tc.expect, tc.cookie.Cookie("id"))
+		})
+	}
+}
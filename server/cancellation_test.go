@@ -0,0 +1,39 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+	"github.com/viant/mcp-protocol/syncmap"
+)
+
+func TestHandler_OnNotificationCancel(t *testing.T) {
+	testCases := []struct {
+		name            string
+		method          string
+		expectCancelled bool
+	}{
+		{name: "notifications/cancelled", method: schema.MethodNotificationCanceled, expectCancelled: true},
+		{name: "legacy cancel", method: schema.MethodNotificationCancel, expectCancelled: true},
+		{name: "unrelated notification", method: "notifications/progress"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := &Server{activeContexts: syncmap.NewMap[int, *activeContext]()}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			srv.activeContexts.Put(7, &activeContext{Context: ctx, CancelFunc: cancel})
+			handler := &Handler{Server: srv, handler: serverproto.NewDefaultHandler(nil, nil, nil)}
+			notification, err := jsonrpc.NewNotification(tc.method, map[string]interface{}{"requestId": 7})
+			if !assert.NoError(t, err) {
+				return
+			}
+			handler.OnNotification(context.Background(), notification)
+			assert.EqualValues(t, tc.expectCancelled, ctx.Err() != nil)
+		})
+	}
+}
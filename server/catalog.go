@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/authorization"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+// maxCatalogPages guards against handlers returning cursors indefinitely.
+const maxCatalogPages = 1000
+
+// Catalog describes what a server offers, independently of the MCP wire protocol.
+type Catalog struct {
+	Server            schema.Implementation     `json:"server"`
+	ProtocolVersion   string                    `json:"protocolVersion"`
+	Instructions      *string                   `json:"instructions,omitempty"`
+	Tools             []schema.Tool             `json:"tools"`
+	Resources         []schema.Resource         `json:"resources"`
+	ResourceTemplates []schema.ResourceTemplate `json:"resourceTemplates"`
+	Prompts           []schema.Prompt           `json:"prompts"`
+}
+
+// Catalog builds the catalog by calling the handler list methods on a detached session.
+// token identifies the principal used for list visibility and authorization; empty means anonymous.
+func (s *Server) Catalog(ctx context.Context, token string) (*Catalog, error) {
+	handler, initResult, err := s.detachedHandler(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	ret := &Catalog{
+		Server:            initResult.ServerInfo,
+		ProtocolVersion:   initResult.ProtocolVersion,
+		Instructions:      initResult.Instructions,
+		Tools:             []schema.Tool{},
+		Resources:         []schema.Resource{},
+		ResourceTemplates: []schema.ResourceTemplate{},
+		Prompts:           []schema.Prompt{},
+	}
+	capabilities := initResult.Capabilities
+
+	if capabilities.Tools != nil || handler.handler.Implements(schema.MethodToolsList) {
+		if err := catalogPages(ctx, handler, token, schema.MethodToolsList, func(result *schema.ListToolsResult) *string {
+			ret.Tools = append(ret.Tools, result.Tools...)
+			return result.NextCursor
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if capabilities.Resources != nil || handler.handler.Implements(schema.MethodResourcesList) {
+		if err := catalogPages(ctx, handler, token, schema.MethodResourcesList, func(result *schema.ListResourcesResult) *string {
+			ret.Resources = append(ret.Resources, result.Resources...)
+			return result.NextCursor
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if handler.implements(schema.MethodResourcesTemplatesList) {
+		if err := catalogPages(ctx, handler, token, schema.MethodResourcesTemplatesList, func(result *schema.ListResourceTemplatesResult) *string {
+			ret.ResourceTemplates = append(ret.ResourceTemplates, result.ResourceTemplates...)
+			return result.NextCursor
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if capabilities.Prompts != nil || handler.handler.Implements(schema.MethodPromptsList) {
+		if err := catalogPages(ctx, handler, token, schema.MethodPromptsList, func(result *schema.ListPromptsResult) *string {
+			ret.Prompts = append(ret.Prompts, result.Prompts...)
+			return result.NextCursor
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// catalogPages calls a paginated list method until no further cursor is returned.
+func catalogPages[R any](ctx context.Context, handler *Handler, token, method string, collect func(result *R) *string) error {
+	var cursor *string
+	for page := 0; page < maxCatalogPages; page++ {
+		params := map[string]interface{}{}
+		if cursor != nil {
+			params["cursor"] = *cursor
+		}
+		result := new(R)
+		if err := catalogCall(ctx, handler, token, method, params, result); err != nil {
+			return err
+		}
+		if cursor = collect(result); cursor == nil || *cursor == "" {
+			return nil
+		}
+	}
+	return fmt.Errorf("%v: too many pages", method)
+}
+
+func catalogCall(ctx context.Context, handler *Handler, token, method string, params interface{}, result interface{}) error {
+	request, err := jsonrpc.NewRequest(method, params)
+	if err != nil {
+		return err
+	}
+	request.Id = handler.nextDetachedRequestID()
+	injectAuthMeta(request, token)
+	if token != "" { // the configured principal is trusted as if set by the authorizer
+		ctx = context.WithValue(ctx, authorization.TokenKey, &authorization.Token{Token: token})
+	}
+	response := &jsonrpc.Response{}
+	handler.Serve(ctx, request, response)
+	if response.Error != nil {
+		return fmt.Errorf("%v: %w", method, response.Error)
+	}
+	return json.Unmarshal(response.Result, result)
+}
+
+// OpenAPI renders the catalog as an OpenAPI 3.1 document: tools as POST /tools/{name},
+// resources as GET /resources?uri= and prompts as GET /prompts/{name}, relative to serverURL,
+// e.g. the WithREST prefix; an empty serverURL means "/".
+func (c *Catalog) OpenAPI(serverURL string) map[string]interface{} {
+	paths := map[string]interface{}{}
+	for _, tool := range c.Tools {
+		var inputSchema map[string]interface{}
+		data, _ := json.Marshal(tool.InputSchema)
+		_ = json.Unmarshal(data, &inputSchema)
+		operation := map[string]interface{}{
+			"operationId": tool.Name,
+			"tags":        []string{"tools"},
+			"requestBody": map[string]interface{}{
+				"required": true,
+				"content":  map[string]interface{}{"application/json": map[string]interface{}{"schema": inputSchema}},
+			},
+			"responses": map[string]interface{}{"200": jsonResponse("tool result", tool.OutputSchema)},
+		}
+		setSummary(operation, tool.Title, tool.Description)
+		paths["/tools/"+tool.Name] = map[string]interface{}{"post": operation}
+	}
+	if len(c.Resources) > 0 || len(c.ResourceTemplates) > 0 {
+		var uris []string
+		for _, resource := range c.Resources {
+			uris = append(uris, resource.Uri)
+		}
+		uriSchema := map[string]interface{}{"type": "string"}
+		description := "resource URI"
+		if len(c.ResourceTemplates) == 0 {
+			uriSchema["enum"] = uris
+		} else {
+			var templates []string
+			for _, template := range c.ResourceTemplates {
+				templates = append(templates, template.UriTemplate)
+			}
+			description += "; listed URIs or URIs matching templates: " + strings.Join(templates, ", ")
+		}
+		paths["/resources"] = map[string]interface{}{"get": map[string]interface{}{
+			"operationId": "readResource",
+			"tags":        []string{"resources"},
+			"parameters": []interface{}{map[string]interface{}{
+				"name": "uri", "in": "query", "required": true, "description": description, "schema": uriSchema,
+			}},
+			"responses": map[string]interface{}{"200": jsonResponse("resource contents", nil)},
+		}}
+	}
+	for _, prompt := range c.Prompts {
+		var parameters []interface{}
+		for _, argument := range prompt.Arguments {
+			parameter := map[string]interface{}{
+				"name": argument.Name, "in": "query", "schema": map[string]interface{}{"type": "string"},
+			}
+			if argument.Required != nil && *argument.Required {
+				parameter["required"] = true
+			}
+			if argument.Description != nil {
+				parameter["description"] = *argument.Description
+			}
+			parameters = append(parameters, parameter)
+		}
+		operation := map[string]interface{}{
+			"operationId": "prompt_" + prompt.Name,
+			"tags":        []string{"prompts"},
+			"responses":   map[string]interface{}{"200": jsonResponse("prompt messages", nil)},
+		}
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+		setSummary(operation, prompt.Title, prompt.Description)
+		paths["/prompts/"+prompt.Name] = map[string]interface{}{"get": operation}
+	}
+	info := map[string]interface{}{"title": c.Server.Name, "version": c.Server.Version}
+	if c.Instructions != nil {
+		info["description"] = *c.Instructions
+	}
+	if serverURL == "" {
+		serverURL = "/"
+	}
+	return map[string]interface{}{
+		"openapi": "3.1.0",
+		"info":    info,
+		"servers": []interface{}{map[string]interface{}{"url": serverURL}},
+		"paths":   paths,
+	}
+}
+
+func setSummary(operation map[string]interface{}, title, description *string) {
+	if title != nil && *title != "" {
+		operation["summary"] = *title
+	}
+	if description != nil && *description != "" {
+		operation["description"] = *description
+	}
+}
+
+func jsonResponse(description string, outputSchema interface{}) map[string]interface{} {
+	aSchema := map[string]interface{}{"type": "object"}
+	if data, err := json.Marshal(outputSchema); err == nil && string(data) != "null" {
+		_ = json.Unmarshal(data, &aSchema)
+	}
+	return map[string]interface{}{
+		"description": description,
+		"content":     map[string]interface{}{"application/json": map[string]interface{}{"schema": aSchema}},
+	}
+}
+
+// catalogHandler serves the JSON catalog at uri and the OpenAPI document at uri + "/openapi.json".
+func (s *Server) catalogHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	catalog, err := s.Catalog(r.Context(), s.catalogToken)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var payload interface{} = catalog
+	if strings.HasSuffix(r.URL.Path, "/openapi.json") {
+		payload = catalog.OpenAPI(s.restPrefix)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(payload)
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/authorization"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+	"github.com/viant/mcp/server/auth"
+)
+
+func TestServer_Catalog(t *testing.T) {
+	policy := &authorization.Policy{
+		Tools: map[string]*authorization.Authorization{"admin": {RequiredScopes: []string{"admin"}}},
+	}
+	newHandler := serverproto.WithDefaultHandler(context.Background(), func(server *serverproto.DefaultHandler) error {
+		noop := func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+			return &schema.CallToolResult{}, nil
+		}
+		server.RegisterToolWithSchema("public", "public tool", schema.ToolInputSchema{Type: "object"},
+			&schema.ToolOutputSchema{Type: "object", Properties: map[string]map[string]interface{}{"sum": {"type": "integer"}}}, noop)
+		server.RegisterToolWithSchema("admin", "admin tool", schema.ToolInputSchema{Type: "object"}, nil, noop)
+		server.RegisterResource(schema.Resource{Name: "readme", Uri: "file:///readme.md"}, func(ctx context.Context, request *schema.ReadResourceRequest) (*schema.ReadResourceResult, *jsonrpc.Error) {
+			return &schema.ReadResourceResult{}, nil
+		})
+		required := true
+		server.RegisterPrompts(&schema.Prompt{Name: "review", Arguments: []schema.PromptArgument{{Name: "code", Required: &required}}},
+			func(ctx context.Context, request *schema.GetPromptRequestParams) (*schema.GetPromptResult, *jsonrpc.Error) {
+				return &schema.GetPromptResult{}, nil
+			})
+		return nil
+	})
+	verifier := func(ctx context.Context, token string) (map[string]any, error) {
+		claims := jwt.MapClaims{}
+		_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) { return []byte("secret"), nil })
+		return claims, err
+	}
+	adminToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "u1", "scope": "admin"}).SignedString([]byte("secret"))
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		token        string
+		restPrefix   string
+		expectTools  []string
+		expectServer string
+	}{
+		{name: "anonymous", expectTools: []string{"public"}, expectServer: "/"},
+		{name: "configured principal", token: adminToken, restPrefix: "/api/", expectTools: []string{"admin", "public"}, expectServer: "/api"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			srv, err := New(WithNewHandler(newHandler), WithListVisibility(auth.NewVisibility(policy, auth.VisibilityHide, auth.WithTokenVerifier(verifier))),
+				WithImplementation(schema.Implementation{Name: "demo", Version: "1.0"}),
+				WithCatalog("/catalog", testCase.token), WithREST(testCase.restPrefix))
+			if !assert.NoError(t, err) {
+				return
+			}
+			httpServer := httptest.NewServer(srv.HTTP(context.Background(), "").Handler)
+			defer httpServer.Close()
+
+			response, err := http.Get(httpServer.URL + "/catalog")
+			if !assert.NoError(t, err) {
+				return
+			}
+			catalog := &Catalog{}
+			assert.NoError(t, json.NewDecoder(response.Body).Decode(catalog))
+			_ = response.Body.Close()
+			var names []string
+			for _, tool := range catalog.Tools {
+				names = append(names, tool.Name)
+			}
+			assert.ElementsMatch(t, testCase.expectTools, names)
+			assert.EqualValues(t, "demo", catalog.Server.Name)
+			assert.Len(t, catalog.Resources, 1)
+			assert.Len(t, catalog.Prompts, 1)
+
+			response, err = http.Get(httpServer.URL + "/catalog/openapi.json")
+			if !assert.NoError(t, err) {
+				return
+			}
+			document := map[string]interface{}{}
+			assert.NoError(t, json.NewDecoder(response.Body).Decode(&document))
+			_ = response.Body.Close()
+			assert.EqualValues(t, "3.1.0", document["openapi"])
+			assert.EqualValues(t, []interface{}{map[string]interface{}{"url": testCase.expectServer}}, document["servers"])
+			paths, _ := document["paths"].(map[string]interface{})
+			assert.Contains(t, paths, "/tools/public")
+			assert.Contains(t, paths, "/resources")
+			assert.Contains(t, paths, "/prompts/review")
+			assert.Equal(t, len(testCase.expectTools)+2, len(paths))
+		})
+	}
+}
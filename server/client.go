@@ -9,11 +9,18 @@ import (
 	"github.com/viant/mcp-protocol/schema"
 )
 
+// ElicitationFallback elicits user input for clients that did not declare the elicitation capability,
+// for example through an out-of-band form.
+type ElicitationFallback interface {
+	Elicit(ctx context.Context, params *schema.ElicitRequestParams) (*schema.ElicitResult, *jsonrpc.Error)
+}
+
 // Client implements mcp-protocol/client.Operations for the handler side. It allows
 // handler implementers to invoke client-side RPC methods over the same transport
 // channel on which the original request arrived.
 type Client struct {
 	implements map[string]bool
+	fallback   ElicitationFallback
 	transport.Transport
 	transport.Sequencer
 }
@@ -69,9 +76,14 @@ func (c *Client) CreateMessage(ctx context.Context, request *jsonrpc.TypedReques
 
 // Experimental/Proposed method names that are not yet part of the stable schema
 
-// Elicit asks the client to elicit additional information from the user.
+// Elicit asks the client to elicit additional information from the user. Clients without the elicitation
+// capability are served by the configured ElicitationFallback, if any.
 func (c *Client) Elicit(ctx context.Context, request *jsonrpc.TypedRequest[*schema.ElicitRequest]) (*schema.ElicitResult, *jsonrpc.Error) {
 	if !c.Implements(schema.MethodElicitationCreate) {
+		if c.fallback != nil {
+			params := request.Request.Params
+			return c.fallback.Elicit(ctx, &params)
+		}
 		return nil, jsonrpc.NewMethodNotFound("method: elicitation/create not implemented by client", nil)
 	}
 	if request.Id == 0 {
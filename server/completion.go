@@ -3,16 +3,44 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+
 	"github.com/viant/jsonrpc"
 	"github.com/viant/mcp-protocol/schema"
+	"github.com/viant/mcp/server/completion"
 )
 
-// Complete handles the completion/complete method
+// Complete handles the completion/complete method; registered completers take precedence over the handler
+// for prompt and resource refs, other ref types are passed to the handler.
 func (h *Handler) Complete(ctx context.Context, request *jsonrpc.Request) (*schema.CompleteResult, *jsonrpc.Error) {
 	completeRequest := &schema.CompleteRequest{Method: schema.MethodComplete}
 	if err := json.Unmarshal(request.Params, &completeRequest.Params); err != nil {
 		return nil, jsonrpc.NewInvalidParamsError(err.Error(), request.Params)
 	}
+	if h.completions != nil {
+		params := &completeRequest.Params
+		if params.Ref.Type != completion.RefPrompt && params.Ref.Type != completion.RefResource {
+			if !h.handler.Implements(schema.MethodComplete) {
+				return nil, jsonrpc.NewInvalidParamsError(fmt.Sprintf("unsupported completion ref type: %v", params.Ref.Type), request.Params)
+			}
+			return h.handlerComplete(ctx, request, completeRequest)
+		}
+		result, ok, err := h.completions.Complete(ctx, params)
+		if err != nil {
+			return nil, jsonrpc.NewInternalError(err.Error(), nil)
+		}
+		if ok {
+			return result, nil
+		}
+		if !h.handler.Implements(schema.MethodComplete) {
+			return completion.NewResult(nil, 0), nil
+		}
+	}
+	return h.handlerComplete(ctx, request, completeRequest)
+}
+
+// handlerComplete passes completion/complete to the handler.
+func (h *Handler) handlerComplete(ctx context.Context, request *jsonrpc.Request, completeRequest *schema.CompleteRequest) (*schema.CompleteResult, *jsonrpc.Error) {
 	id, _ := jsonrpc.AsRequestIntId(request.Id)
 	return h.handler.Complete(ctx, &jsonrpc.TypedRequest[*schema.CompleteRequest]{Request: completeRequest, Id: uint64(id)})
 }
@@ -0,0 +1,113 @@
+package completion
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/viant/afs"
+	"github.com/viant/afs/url"
+)
+
+// Request represents a completion request of a single argument.
+type Request struct {
+	// Argument is the name of the completed argument.
+	Argument string
+	// Value is the partial value typed so far.
+	Value string
+	// Arguments holds previously resolved arguments of the prompt or resource template.
+	Arguments map[string]string
+}
+
+// Completer returns candidate values for an argument.
+type Completer interface {
+	Complete(ctx context.Context, request *Request) ([]string, error)
+}
+
+// Func adapts a function to Completer.
+type Func func(ctx context.Context, request *Request) ([]string, error)
+
+// Complete calls fn.
+func (fn Func) Complete(ctx context.Context, request *Request) ([]string, error) {
+	return fn(ctx, request)
+}
+
+// Enum completes from a static list of values matching the typed prefix.
+func Enum(values ...string) Completer {
+	return Func(func(ctx context.Context, request *Request) ([]string, error) {
+		return filterPrefix(values, request.Value), nil
+	})
+}
+
+// Prefix completes from the values returned by provider that match the typed prefix.
+func Prefix(provider func(ctx context.Context, request *Request) ([]string, error)) Completer {
+	return Func(func(ctx context.Context, request *Request) ([]string, error) {
+		values, err := provider(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		return filterPrefix(values, request.Value), nil
+	})
+}
+
+// Path completes file system paths relative to baseURL; directories end with a slash. Values escaping
+// baseURL yield no candidates. A nil fs uses afs.New().
+func Path(fs afs.Service, baseURL string) Completer {
+	if fs == nil {
+		fs = afs.New()
+	}
+	return Func(func(ctx context.Context, request *Request) ([]string, error) {
+		dir, prefix := "", request.Value
+		if index := strings.LastIndex(request.Value, "/"); index != -1 {
+			dir, prefix = request.Value[:index+1], request.Value[index+1:]
+		}
+		location := baseURL
+		if dir != "" {
+			cleaned := path.Clean(dir)
+			if path.IsAbs(dir) || cleaned+"/" != dir || cleaned == "." || strings.HasPrefix(cleaned, "..") {
+				return nil, nil // absolute, parent or non canonical directories are not completed
+			}
+			location = url.Join(baseURL, cleaned)
+		}
+		if ok, _ := fs.Exists(ctx, location); !ok {
+			return nil, nil
+		}
+		objects, err := fs.List(ctx, location)
+		if err != nil {
+			return nil, err
+		}
+		var ret []string
+		for _, object := range objects {
+			if url.Equals(object.URL(), location) {
+				continue // the listed directory itself
+			}
+			name := object.Name()
+			if !hasPrefix(name, prefix) {
+				continue
+			}
+			if object.IsDir() {
+				name += "/"
+			}
+			ret = append(ret, dir+name)
+		}
+		return ret, nil
+	})
+}
+
+func filterPrefix(values []string, prefix string) []string {
+	if prefix == "" {
+		return values
+	}
+	var ret []string
+	for _, value := range values {
+		if hasPrefix(value, prefix) {
+			ret = append(ret, value)
+		}
+	}
+	return ret
+}
+
+// hasPrefix reports whether value starts with prefix, ignoring case.
+func hasPrefix(value, prefix string) bool {
+	return len(value) >= len(prefix) && strings.EqualFold(value[:len(prefix)], prefix)
+}
@@ -0,0 +1,9 @@
+// Package completion provides argument completion for prompts and resource templates.
+//
+// A Registry maps a prompt name or a resource URI template and an argument name to a
+// Completer. Enum completes from a static list, Prefix filters values returned by a
+// provider function and Path completes file system paths under a base URL. The server
+// routes completion/complete requests to the registry when configured with
+// server.WithCompletions and advertises the completions capability; requests without a
+// registered completer fall back to the handler Complete method.
+package completion
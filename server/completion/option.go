@@ -0,0 +1,13 @@
+package completion
+
+// Option customizes Registry.
+type Option func(r *Registry)
+
+// WithLimit sets the maximum number of values per response (default and upper bound MaxValues).
+func WithLimit(limit int) Option {
+	return func(r *Registry) {
+		if limit > 0 && limit < MaxValues {
+			r.limit = limit
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package completion
+
+import (
+	"context"
+	"sync"
+
+	"github.com/viant/mcp-protocol/schema"
+)
+
+const (
+	// RefPrompt references a prompt by name.
+	RefPrompt = "ref/prompt"
+	// RefResource references a resource template by URI template.
+	RefResource = "ref/resource"
+	// MaxValues is the maximum number of values returned in a completion response.
+	MaxValues = 100
+)
+
+// Registry holds per-argument completers of prompts and resource templates.
+type Registry struct {
+	mu        sync.RWMutex
+	prompts   map[string]map[string]Completer
+	resources map[string]map[string]Completer
+	limit     int
+}
+
+// NewRegistry creates a completion registry.
+func NewRegistry(options ...Option) *Registry {
+	ret := &Registry{
+		prompts:   make(map[string]map[string]Completer),
+		resources: make(map[string]map[string]Completer),
+		limit:     MaxValues,
+	}
+	for _, option := range options {
+		option(ret)
+	}
+	return ret
+}
+
+// RegisterPrompt registers a completer for a prompt argument.
+func (r *Registry) RegisterPrompt(name, argument string, completer Completer) {
+	r.register(r.prompts, name, argument, completer)
+}
+
+// RegisterResource registers a completer for a resource template variable.
+func (r *Registry) RegisterResource(uriTemplate, argument string, completer Completer) {
+	r.register(r.resources, uriTemplate, argument, completer)
+}
+
+func (r *Registry) register(refs map[string]map[string]Completer, ref, argument string, completer Completer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	arguments, ok := refs[ref]
+	if !ok {
+		arguments = make(map[string]Completer)
+		refs[ref] = arguments
+	}
+	arguments[argument] = completer
+}
+
+// Lookup returns the completer registered for the referenced argument.
+func (r *Registry) Lookup(ref *schema.CompleteRequestParamsRef, argument string) (Completer, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var arguments map[string]Completer
+	switch ref.Type {
+	case RefPrompt:
+		arguments = r.prompts[ref.Name]
+	case RefResource:
+		arguments = r.resources[ref.Uri]
+	}
+	completer, ok := arguments[argument]
+	return completer, ok
+}
+
+// Complete runs the registered completer; ok is false when no completer matches the request.
+// Values are truncated to the registry limit with total and hasMore set accordingly.
+func (r *Registry) Complete(ctx context.Context, params *schema.CompleteRequestParams) (result *schema.CompleteResult, ok bool, err error) {
+	completer, ok := r.Lookup(&params.Ref, params.Argument.Name)
+	if !ok {
+		return nil, false, nil
+	}
+	request := &Request{Argument: params.Argument.Name, Value: params.Argument.Value}
+	if params.Context != nil {
+		request.Arguments = params.Context.Arguments
+	}
+	values, err := completer.Complete(ctx, request)
+	if err != nil {
+		return nil, true, err
+	}
+	return NewResult(values, r.limit), true, nil
+}
+
+// NewResult returns a completion result with at most limit values.
+func NewResult(values []string, limit int) *schema.CompleteResult {
+	total := len(values)
+	hasMore := limit > 0 && total > limit
+	if hasMore {
+		values = values[:limit]
+	}
+	if values == nil {
+		values = []string{}
+	}
+	return &schema.CompleteResult{Completion: schema.CompleteResultCompletion{Values: values, Total: &total, HasMore: &hasMore}}
+}
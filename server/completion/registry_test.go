@@ -0,0 +1,72 @@
+package completion
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+func TestRegistry_Complete(t *testing.T) {
+	baseDir := t.TempDir()
+	for _, name := range []string{"docs/guide.md", "docs/api.md", "go.mod", "go.sum", "main.go"} {
+		location := filepath.Join(baseDir, name)
+		_ = os.MkdirAll(filepath.Dir(location), 0o755)
+		_ = os.WriteFile(location, []byte("x"), 0o644)
+	}
+	var many []string
+	for i := 0; i < 150; i++ {
+		many = append(many, fmt.Sprintf("item-%03d", i))
+	}
+	registry := NewRegistry()
+	registry.RegisterPrompt("review", "language", Enum("go", "golang", "python", "rust"))
+	registry.RegisterPrompt("review", "file", Path(nil, baseDir))
+	registry.RegisterPrompt("catalog", "item", Prefix(func(ctx context.Context, request *Request) ([]string, error) {
+		return many, nil
+	}))
+	registry.RegisterResource("repo://{owner}/{name}", "name", Prefix(func(ctx context.Context, request *Request) ([]string, error) {
+		return map[string][]string{"viant": {"mcp", "afs", "jsonrpc"}}[request.Arguments["owner"]], nil
+	}))
+
+	testCases := []struct {
+		name          string
+		ref           schema.CompleteRequestParamsRef
+		argument      string
+		value         string
+		arguments     map[string]string
+		expectFound   bool
+		expectValues  []string
+		expectTotal   int
+		expectHasMore bool
+	}{
+		{name: "enum prefix", ref: schema.CompleteRequestParamsRef{Type: RefPrompt, Name: "review"}, argument: "language", value: "GO", expectFound: true, expectValues: []string{"go", "golang"}, expectTotal: 2},
+		{name: "path root", ref: schema.CompleteRequestParamsRef{Type: RefPrompt, Name: "review"}, argument: "file", value: "go.", expectFound: true, expectValues: []string{"go.mod", "go.sum"}, expectTotal: 2},
+		{name: "path nested", ref: schema.CompleteRequestParamsRef{Type: RefPrompt, Name: "review"}, argument: "file", value: "docs/g", expectFound: true, expectValues: []string{"docs/guide.md"}, expectTotal: 1},
+		{name: "path directory", ref: schema.CompleteRequestParamsRef{Type: RefPrompt, Name: "review"}, argument: "file", value: "do", expectFound: true, expectValues: []string{"docs/"}, expectTotal: 1},
+		{name: "path escape", ref: schema.CompleteRequestParamsRef{Type: RefPrompt, Name: "review"}, argument: "file", value: "../", expectFound: true, expectValues: []string{}},
+		{name: "limit", ref: schema.CompleteRequestParamsRef{Type: RefPrompt, Name: "catalog"}, argument: "item", value: "item-", expectFound: true, expectValues: many[:MaxValues], expectTotal: 150, expectHasMore: true},
+		{name: "resource context", ref: schema.CompleteRequestParamsRef{Type: RefResource, Uri: "repo://{owner}/{name}"}, argument: "name", value: "j", arguments: map[string]string{"owner": "viant"}, expectFound: true, expectValues: []string{"jsonrpc"}, expectTotal: 1},
+		{name: "unknown argument", ref: schema.CompleteRequestParamsRef{Type: RefPrompt, Name: "review"}, argument: "style"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			params := &schema.CompleteRequestParams{Ref: testCase.ref, Argument: schema.CompleteRequestParamsArgument{Name: testCase.argument, Value: testCase.value}}
+			if testCase.arguments != nil {
+				params.Context = &schema.CompleteRequestParamsContext{Arguments: testCase.arguments}
+			}
+			result, found, err := registry.Complete(context.Background(), params)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectFound, found)
+			if !found {
+				return
+			}
+			assert.ElementsMatch(t, testCase.expectValues, result.Completion.Values)
+			assert.Equal(t, testCase.expectTotal, *result.Completion.Total)
+			assert.Equal(t, testCase.expectHasMore, *result.Completion.HasMore)
+		})
+	}
+}
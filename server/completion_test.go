@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/jsonrpc/transport"
+	pclient "github.com/viant/mcp-protocol/client"
+	"github.com/viant/mcp-protocol/logger"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+	"github.com/viant/mcp/server/completion"
+)
+
+// refCompleter completes refs other than prompts and resources.
+type refCompleter struct {
+	*serverproto.DefaultHandler
+}
+
+func (h *refCompleter) Complete(ctx context.Context, request *jsonrpc.TypedRequest[*schema.CompleteRequest]) (*schema.CompleteResult, *jsonrpc.Error) {
+	return completion.NewResult([]string{request.Request.Params.Ref.Type}, 1), nil
+}
+
+func (h *refCompleter) Implements(method string) bool {
+	return method == schema.MethodComplete || h.DefaultHandler.Implements(method)
+}
+
+func TestServer_Completions(t *testing.T) {
+	registry := completion.NewRegistry()
+	registry.RegisterPrompt("review", "language", completion.Enum("go", "python"))
+	srv, err := New(WithNewHandler(serverproto.WithDefaultHandler(context.Background())), WithCompletions(registry))
+	if !assert.NoError(t, err) {
+		return
+	}
+	ctx := context.Background()
+	aClient := srv.InProcessClient(ctx, nil)
+	initialized, err := aClient.Initialize(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NotNil(t, initialized.Capabilities.Completions)
+	response := &jsonrpc.Response{}
+	srv.newHandler(ctx, nil).Serve(ctx, &jsonrpc.Request{Jsonrpc: jsonrpc.Version, Id: 1, Method: schema.MethodInitialize, Params: []byte(`{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1.0"}}`)}, response)
+	assert.Contains(t, string(response.Result), `"completions":{}`)
+	assert.Contains(t, string(response.Result), `"protocolVersion":"2025-06-18"`)
+	assert.Equal(t, 1, strings.Count(string(response.Result), `"capabilities"`))
+
+	handlerSrv, err := New(WithNewHandler(func(ctx context.Context, notifier transport.Notifier, logger logger.Logger, client pclient.Operations) (serverproto.Handler, error) {
+		return &refCompleter{DefaultHandler: serverproto.NewDefaultHandler(notifier, logger, client)}, nil
+	}), WithCompletions(registry))
+	if !assert.NoError(t, err) {
+		return
+	}
+	handlerClient := handlerSrv.InProcessClient(ctx, nil)
+
+	testCases := []struct {
+		name         string
+		ref          schema.CompleteRequestParamsRef
+		argument     string
+		handler      bool
+		expectValues []string
+		expectErr    bool
+	}{
+		{name: "registered", ref: schema.CompleteRequestParamsRef{Type: completion.RefPrompt, Name: "review"}, argument: "language", expectValues: []string{"go"}},
+		{name: "not registered", ref: schema.CompleteRequestParamsRef{Type: completion.RefPrompt, Name: "summary"}, argument: "language", expectValues: []string{}},
+		{name: "invalid ref", ref: schema.CompleteRequestParamsRef{Type: "ref/tool", Name: "review"}, argument: "language", expectErr: true},
+		{name: "other ref served by handler", handler: true, ref: schema.CompleteRequestParamsRef{Type: "ref/tool", Name: "review"}, argument: "language", expectValues: []string{"ref/tool"}},
+		{name: "registered ahead of handler", handler: true, ref: schema.CompleteRequestParamsRef{Type: completion.RefPrompt, Name: "review"}, argument: "language", expectValues: []string{"go"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			completer := aClient
+			if testCase.handler {
+				completer = handlerClient
+			}
+			result, err := completer.Complete(ctx, &schema.CompleteRequestParams{Ref: testCase.ref, Argument: schema.CompleteRequestParamsArgument{Name: testCase.argument, Value: "g"}})
+			if testCase.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, testCase.expectValues, result.Completion.Values)
+			}
+		})
+	}
+}
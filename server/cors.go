@@ -3,6 +3,7 @@ package server
 import (
 	"github.com/viant/scy/auth/flow"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 )
@@ -12,12 +13,24 @@ const (
 	AllowHeadersHeader      = "Access-Control-Allow-Headers"
 	AllowMethodsHeader      = "Access-Control-Allow-Methods"
 	AllControlRequestHeader = "Access-Control-Request-Method"
+	RequestHeadersHeader    = "Access-Control-Request-Headers"
 	AllowCredentialsHeader  = "Access-Control-Allow-Credentials"
 	ExposeHeadersHeader     = "Access-Control-Expose-Headers"
 	MaxAgeHeader            = "Access-Control-Max-Age"
+	VaryHeader              = "Vary"
 	Separator               = ", "
 )
 
+// defaultExposeHeaders lists response headers exposed when ExposeHeaders is "*".
+var defaultExposeHeaders = []string{"Content-Type", "Authorization", "WWW-Authenticate", "Mcp-Session-Id", "MCP-Protocol-Version", flow.AuthorizationExchangeHeader}
+
+// Cors represents a CORS policy.
+//
+// AllowOrigins entries are exact origins (https://app.example.com), wildcard subdomain patterns
+// (https://*.example.com, where the scheme is optional and the port may be "*") or "*" for any origin.
+// Credentials are only allowed for origins matched by an explicit entry: an origin matched by "*" alone
+// receives "Access-Control-Allow-Origin: *" without credentials. Empty AllowMethods or "*" allows the
+// requested method; AllowHeaders "*" allows the requested headers.
 type Cors struct {
 	AllowCredentials *bool    `yaml:"AllowCredentials,omitempty"`
 	AllowHeaders     []string `yaml:"AllowHeaders,omitempty"`
@@ -35,73 +48,211 @@ func (c *Cors) OriginMap() map[string]bool {
 	return result
 }
 
-// corsHandler is a handler that sets CORS headers
+// AllowsOrigin reports whether origin matches any of the allowed origins.
+func (c *Cors) AllowsOrigin(origin string) bool {
+	return originAllowed(c.AllowOrigins, origin)
+}
+
+// allowOrigin returns the Access-Control-Allow-Origin value for origin and whether credentials are
+// allowed; the value is empty when the origin is not allowed.
+func (c *Cors) allowOrigin(origin string) (string, bool) {
+	wildcard := false
+	for _, pattern := range c.AllowOrigins {
+		if pattern == "*" {
+			wildcard = true
+			continue
+		}
+		if origin != "" && matchOrigin(pattern, origin) {
+			return origin, c.AllowCredentials != nil && *c.AllowCredentials
+		}
+	}
+	if wildcard {
+		return "*", false
+	}
+	return "", false
+}
+
+// corsHandler is a handler that sets CORS headers and answers preflight requests
 type corsHandler struct {
 	*Cors
 }
 
 func (h *corsHandler) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.Cors == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if isPreflight(r) {
+			h.Cors.preflight(w, r)
+			return
+		}
 		h.Cors.setHeaders(w, r)
 		next.ServeHTTP(w, r)
 	})
 }
 
+func isPreflight(request *http.Request) bool {
+	return request.Method == http.MethodOptions && request.Header.Get("Origin") != "" && request.Header.Get(AllControlRequestHeader) != ""
+}
+
+// preflight answers a CORS preflight request without invoking the route handler.
+func (c *Cors) preflight(writer http.ResponseWriter, request *http.Request) {
+	addVary(writer.Header(), "Origin", AllControlRequestHeader, RequestHeadersHeader)
+	allowOrigin, credentials := c.allowOrigin(request.Header.Get("Origin"))
+	if allowOrigin == "" {
+		http.Error(writer, "origin not allowed", http.StatusForbidden)
+		return
+	}
+	method := request.Header.Get(AllControlRequestHeader)
+	if !c.allowsMethod(method) {
+		http.Error(writer, "method not allowed", http.StatusForbidden)
+		return
+	}
+	header := writer.Header()
+	header.Set(AllowOriginHeader, allowOrigin)
+	if credentials {
+		header.Set(AllowCredentialsHeader, "true")
+	}
+	if len(c.AllowMethods) == 0 || contains(c.AllowMethods, "*") {
+		header.Set(AllowMethodsHeader, method)
+	} else {
+		header.Set(AllowMethodsHeader, strings.Join(c.AllowMethods, Separator))
+	}
+	if contains(c.AllowHeaders, "*") {
+		if requested := request.Header.Get(RequestHeadersHeader); requested != "" {
+			header.Set(AllowHeadersHeader, requested)
+		}
+	} else if len(c.AllowHeaders) > 0 {
+		header.Set(AllowHeadersHeader, strings.Join(c.AllowHeaders, Separator))
+	}
+	if c.MaxAge != nil {
+		header.Set(MaxAgeHeader, strconv.Itoa(int(*c.MaxAge)))
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// setHeaders sets CORS headers of an actual (non-preflight) request.
 func (c *Cors) setHeaders(writer http.ResponseWriter, request *http.Request) {
 	if c == nil {
 		return
 	}
-	origin := request.Header.Get("Origin")
-	allowedOrigins := c.OriginMap()
-	if allowedOrigins["*"] {
-		if origin == "" {
-			writer.Header().Set(AllowOriginHeader, "*")
-		} else {
-			writer.Header().Set(AllowOriginHeader, origin)
-		}
-	} else {
-		if origin != "" && allowedOrigins[origin] {
-			writer.Header().Set(AllowOriginHeader, origin)
+	header := writer.Header()
+	addVary(header, "Origin")
+	allowOrigin, credentials := c.allowOrigin(request.Header.Get("Origin"))
+	if allowOrigin == "" {
+		return
+	}
+	header.Set(AllowOriginHeader, allowOrigin)
+	if credentials {
+		header.Set(AllowCredentialsHeader, "true")
+	}
+	if len(c.ExposeHeaders) > 0 {
+		exposedHeaders := c.ExposeHeaders
+		if contains(exposedHeaders, "*") {
+			exposedHeaders = defaultExposeHeaders
 		}
+		header.Set(ExposeHeadersHeader, strings.Join(exposedHeaders, Separator))
 	}
-	if c.AllowMethods != nil {
-		writer.Header().Set(AllowMethodsHeader, request.Method)
+}
+
+func (c *Cors) allowsMethod(method string) bool {
+	if len(c.AllowMethods) == 0 || method == http.MethodOptions {
+		return true
 	}
-	if request.Method == "OPTIONS" {
-		requestMethod := request.Header.Get(AllControlRequestHeader)
-		if requestMethod != "" {
-			writer.Header().Set(AllowMethodsHeader, requestMethod)
+	for _, candidate := range c.AllowMethods {
+		if candidate == "*" || strings.EqualFold(candidate, method) {
+			return true
 		}
 	}
-	if len(c.AllowHeaders) > 0 {
-		allowedHeaders := strings.Join(c.AllowHeaders, Separator)
-		if allowedHeaders == "*" {
-			allowedHeaders = "Content-Type,Authorization," + flow.AuthorizationExchangeHeader
+	return false
+}
+
+// originAllowed reports whether origin matches any of patterns.
+func originAllowed(patterns []string, origin string) bool {
+	for _, pattern := range patterns {
+		if matchOrigin(pattern, origin) {
+			return true
 		}
-		writer.Header().Set(AllowHeadersHeader, allowedHeaders)
 	}
-	if c.AllowCredentials != nil {
-		writer.Header().Set(AllowCredentialsHeader, strconv.FormatBool(*c.AllowCredentials))
+	return false
+}
+
+// matchOrigin matches origin against an exact origin, "*" or a wildcard subdomain pattern.
+func matchOrigin(pattern, origin string) bool {
+	if pattern == "*" || strings.EqualFold(pattern, origin) {
+		return true
 	}
-	if c.MaxAge != nil {
-		writer.Header().Set(MaxAgeHeader, strconv.Itoa(int(*c.MaxAge)))
+	if !strings.Contains(pattern, "*") {
+		return false
 	}
-	if len(c.ExposeHeaders) > 0 {
-		exposedHeaders := strings.Join(c.ExposeHeaders, Separator)
-		if exposedHeaders == "*" {
-			exposedHeaders = "Content-Type,Authorization," + flow.AuthorizationExchangeHeader
+	parsed, err := url.Parse(origin)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+	scheme, hostPort, ok := strings.Cut(pattern, "://")
+	if !ok {
+		scheme, hostPort = "", pattern
+	}
+	if scheme != "" && !strings.EqualFold(scheme, parsed.Scheme) {
+		return false
+	}
+	host, port := hostPort, ""
+	if index := strings.LastIndex(hostPort, ":"); index > strings.LastIndex(hostPort, "]") {
+		host, port = hostPort[:index], hostPort[index+1:]
+	}
+	if port != "*" && port != parsed.Port() {
+		return false
+	}
+	host, hostname := strings.ToLower(host), strings.ToLower(parsed.Hostname())
+	if suffix, ok := strings.CutPrefix(host, "*."); ok {
+		return strings.HasSuffix(hostname, "."+suffix) && len(hostname) > len(suffix)+1
+	}
+	return host == hostname
+}
+
+func addVary(header http.Header, names ...string) {
+	existing := strings.Join(header.Values(VaryHeader), ",")
+	for _, name := range names {
+		found := false
+		for _, value := range strings.Split(existing, ",") {
+			if strings.EqualFold(strings.TrimSpace(value), name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			header.Add(VaryHeader, name)
 		}
-		writer.Header().Set(ExposeHeadersHeader, exposedHeaders)
 	}
 }
 
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultCors allows any origin without credentials.
 func defaultCors() *Cors {
 	ret := &Cors{
-		AllowCredentials: &[]bool{true}[0],
-		AllowHeaders:     []string{"*"},
-		AllowMethods:     []string{"*"},
-		AllowOrigins:     []string{"*"},
-		ExposeHeaders:    []string{"*"},
+		AllowHeaders:  []string{"*"},
+		AllowMethods:  []string{"*"},
+		AllowOrigins:  []string{"*"},
+		ExposeHeaders: []string{"*"},
 	}
 	return ret
 }
+
+// metadataCors allows any origin to read well-known metadata without credentials.
+func metadataCors() *Cors {
+	return &Cors{
+		AllowHeaders:  []string{"*"},
+		AllowMethods:  []string{http.MethodGet, http.MethodOptions},
+		AllowOrigins:  []string{"*"},
+		ExposeHeaders: []string{"*"},
+	}
+}
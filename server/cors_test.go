@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	serverproto "github.com/viant/mcp-protocol/server"
+)
+
+func TestServer_CORS(t *testing.T) {
+	newHandler := serverproto.WithDefaultHandler(context.Background())
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+	srv, err := New(WithNewHandler(newHandler),
+		WithCORS(&Cors{AllowOrigins: []string{"https://*.example.com", "http://localhost:*"}, AllowCredentials: &[]bool{true}[0], AllowHeaders: []string{"*"}, AllowMethods: []string{"GET", "POST", "DELETE"}}),
+		WithProtectedResourcesHandler(ok),
+		WithCustomHTTPHandler("/public", ok),
+		WithCustomHTTPHandler("/internal", ok),
+		WithRouteCORS("/public", &Cors{AllowOrigins: []string{"*"}}),
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+	handler := srv.HTTP(context.Background(), "").Handler
+
+	testCases := []struct {
+		name              string
+		method            string
+		path              string
+		origin            string
+		requestMethod     string
+		requestHeaders    string
+		expectStatus      int
+		expectOrigin      string
+		expectCredentials string
+		expectHeaders     string
+	}{
+		{name: "preflight wildcard subdomain", method: http.MethodOptions, path: "/mcp", origin: "https://app.example.com", requestMethod: "POST", requestHeaders: "Mcp-Session-Id, Content-Type", expectStatus: http.StatusNoContent, expectOrigin: "https://app.example.com", expectCredentials: "true", expectHeaders: "Mcp-Session-Id, Content-Type"},
+		{name: "preflight any port", method: http.MethodOptions, path: "/sse", origin: "http://localhost:3000", requestMethod: "GET", expectStatus: http.StatusNoContent, expectOrigin: "http://localhost:3000", expectCredentials: "true"},
+		{name: "preflight bare domain rejected", method: http.MethodOptions, path: "/mcp", origin: "https://example.com", requestMethod: "POST", expectStatus: http.StatusForbidden},
+		{name: "preflight method rejected", method: http.MethodOptions, path: "/mcp", origin: "https://app.example.com", requestMethod: "PUT", expectStatus: http.StatusForbidden},
+		{name: "actual request from unknown origin", method: http.MethodGet, path: "/mcp", origin: "https://evil.test", expectStatus: http.StatusForbidden},
+		{name: "metadata public without credentials", method: http.MethodGet, path: "/.well-known/oauth-protected-resource", origin: "https://evil.test", expectStatus: http.StatusOK, expectOrigin: "*"},
+		{name: "custom route policy", method: http.MethodOptions, path: "/public", origin: "https://evil.test", requestMethod: "GET", expectStatus: http.StatusNoContent, expectOrigin: "*"},
+		{name: "custom route without policy", method: http.MethodGet, path: "/internal", origin: "https://app.example.com", expectStatus: http.StatusOK},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest(tc.method, tc.path, nil)
+			request.Host = "localhost:5000"
+			request.Header.Set("Origin", tc.origin)
+			if tc.requestMethod != "" {
+				request.Header.Set(AllControlRequestHeader, tc.requestMethod)
+			}
+			if tc.requestHeaders != "" {
+				request.Header.Set(RequestHeadersHeader, tc.requestHeaders)
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			assert.EqualValues(t, tc.expectStatus, recorder.Code)
+			assert.EqualValues(t, tc.expectOrigin, recorder.Header().Get(AllowOriginHeader))
+			assert.EqualValues(t, tc.expectCredentials, recorder.Header().Get(AllowCredentialsHeader))
+			assert.EqualValues(t, tc.expectHeaders, recorder.Header().Get(AllowHeadersHeader))
+		})
+	}
+}
+
+func TestMatchOrigin(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		origin  string
+		expect  bool
+	}{
+		{pattern: "*", origin: "https://any.test", expect: true},
+		{pattern: "https://app.example.com", origin: "https://APP.example.com", expect: true},
+		{pattern: "https://*.example.com", origin: "https://a.b.example.com", expect: true},
+		{pattern: "https://*.example.com", origin: "http://a.example.com", expect: false},
+		{pattern: "https://*.example.com", origin: "https://a.example.com:8443", expect: false},
+		{pattern: "https://*.example.com", origin: "https://badexample.com", expect: false},
+		{pattern: "*.example.com", origin: "http://a.example.com", expect: true},
+		{pattern: "http://localhost:*", origin: "http://localhost:5173", expect: true},
+		{pattern: "http://localhost:*", origin: "http://localhost.evil.test:5173", expect: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.pattern+" "+tc.origin, func(t *testing.T) {
+			assert.EqualValues(t, tc.expect, matchOrigin(tc.pattern, tc.origin))
+		})
+	}
+}
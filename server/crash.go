@@ -0,0 +1,191 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	"github.com/viant/mcp/internal/sessionid"
+	"github.com/viant/mcp/server/task"
+)
+
+// Crash describes a panic recovered while dispatching a request or notification.
+type Crash struct {
+	Time      time.Time   `json:"time"`
+	Method    string      `json:"method"`
+	Tool      string      `json:"tool,omitempty"`
+	RequestID interface{} `json:"requestId,omitempty"`
+	SessionID string      `json:"sessionId,omitempty"`
+	Value     string      `json:"value"`
+	Stack     string      `json:"stack"`
+}
+
+// CrashReporter records recovered panics.
+type CrashReporter interface {
+	Report(ctx context.Context, crash *Crash)
+}
+
+// CrashReporterFunc adapts a function to the CrashReporter interface.
+type CrashReporterFunc func(ctx context.Context, crash *Crash)
+
+// Report calls f(ctx, crash).
+func (f CrashReporterFunc) Report(ctx context.Context, crash *Crash) {
+	f(ctx, crash)
+}
+
+// NewWriterCrashReporter returns a reporter writing crashes with their stack to writer.
+func NewWriterCrashReporter(writer io.Writer) CrashReporter {
+	var mu sync.Mutex
+	return CrashReporterFunc(func(ctx context.Context, crash *Crash) {
+		mu.Lock()
+		defer mu.Unlock()
+		_, _ = fmt.Fprintf(writer, "%v panic in %v (tool: %v, request: %v, session: %v): %v\n%s\n",
+			crash.Time.Format(time.RFC3339), crash.Method, crash.Tool, crash.RequestID, crash.SessionID, crash.Value, crash.Stack)
+	})
+}
+
+var stderrCrashReporter = NewWriterCrashReporter(os.Stderr)
+
+// newCrash captures the recovered value and the current stack.
+func (h *Handler) newCrash(ctx context.Context, method string, id interface{}, value interface{}) *Crash {
+	return &Crash{
+		Time:      time.Now().UTC(),
+		Method:    method,
+		RequestID: id,
+		SessionID: sessionid.FromContext(ctx),
+		Value:     fmt.Sprint(value),
+		Stack:     string(debug.Stack()),
+	}
+}
+
+// reportCrash passes crash to the configured reporter, or to the MCP logger and stderr by default.
+func (h *Handler) reportCrash(ctx context.Context, crash *Crash) {
+	if h.crashReporter != nil {
+		h.crashReporter.Report(ctx, crash)
+		return
+	}
+	if h.Logger != nil {
+		_ = h.Logger.Error(ctx, map[string]interface{}{"panic": crash.Value, "method": crash.Method, "tool": crash.Tool})
+	}
+	stderrCrashReporter.Report(ctx, crash)
+}
+
+// recoverRequest converts a panic raised while serving request into an internal error.
+func (h *Handler) recoverRequest(ctx context.Context, request *jsonrpc.Request, response *jsonrpc.Response) {
+	value := recover()
+	if value == nil {
+		return
+	}
+	h.reportRequestCrash(ctx, request, value)
+	response.Result = nil
+	response.Error = jsonrpc.NewInternalError(fmt.Sprintf("internal error: %v panicked", request.Method), nil)
+}
+
+// recoverTask returns the panic handler of tasks started while serving request.
+func (h *Handler) recoverTask(request *jsonrpc.Request) task.PanicHandler {
+	return func(ctx context.Context, value interface{}) *jsonrpc.Error {
+		h.reportRequestCrash(ctx, request, value)
+		return jsonrpc.NewInternalError(fmt.Sprintf("internal error: %v task panicked", request.Method), nil)
+	}
+}
+
+// reportRequestCrash reports a panic raised while serving request; a tools/call panic counts against the tool health.
+func (h *Handler) reportRequestCrash(ctx context.Context, request *jsonrpc.Request, value interface{}) {
+	crash := h.newCrash(ctx, request.Method, request.Id, value)
+	if request.Method == schema.MethodToolsCall {
+		crash.Tool = toolName(request)
+		h.toolHealth.panicked(crash.Tool)
+	}
+	h.reportCrash(ctx, crash)
+}
+
+// recoverNotification reports a panic raised while handling notification.
+func (h *Handler) recoverNotification(ctx context.Context, notification *jsonrpc.Notification) {
+	if value := recover(); value != nil {
+		h.reportCrash(ctx, h.newCrash(ctx, notification.Method, nil, value))
+	}
+}
+
+// toolHealth marks tools unhealthy after repeated consecutive panics.
+type toolHealth struct {
+	threshold int
+	cooldown  time.Duration
+	mu        sync.Mutex
+	tools     map[string]*toolState
+}
+
+type toolState struct {
+	panics    int
+	lastPanic time.Time
+}
+
+func newToolHealth(threshold int, cooldown time.Duration) *toolHealth {
+	return &toolHealth{threshold: threshold, cooldown: cooldown, tools: map[string]*toolState{}}
+}
+
+// panicked records a tool panic.
+func (t *toolHealth) panicked(tool string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state, ok := t.tools[tool]
+	if !ok {
+		state = &toolState{}
+		t.tools[tool] = state
+	}
+	state.panics++
+	state.lastPanic = time.Now()
+}
+
+// succeeded resets the panic count of a tool.
+func (t *toolHealth) succeeded(tool string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.tools, tool)
+}
+
+// healthy returns false when tool reached the panic threshold and the cooldown has not elapsed yet.
+func (t *toolHealth) healthy(tool string) bool {
+	if t == nil {
+		return true
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state, ok := t.tools[tool]
+	if !ok || state.panics < t.threshold {
+		return true
+	}
+	return t.cooldown > 0 && time.Since(state.lastPanic) >= t.cooldown
+}
+
+// unhealthy returns the names of tools currently rejected.
+func (t *toolHealth) unhealthy() []string {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var ret []string
+	for name, state := range t.tools {
+		if state.panics >= t.threshold && (t.cooldown <= 0 || time.Since(state.lastPanic) < t.cooldown) {
+			ret = append(ret, name)
+		}
+	}
+	return ret
+}
+
+// UnhealthyTools returns tools rejected after repeated panics; see WithToolHealth.
+func (s *Server) UnhealthyTools() []string {
+	return s.toolHealth.unhealthy()
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+	"github.com/viant/mcp/server/task"
+)
+
+func TestServer_PanicRecovery(t *testing.T) {
+	var mu sync.Mutex
+	runs := 0
+	newHandler := serverproto.WithDefaultHandler(context.Background(), func(server *serverproto.DefaultHandler) error {
+		server.RegisterToolWithSchema("boom", "always panics", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				mu.Lock()
+				runs++
+				mu.Unlock()
+				panic("nil map")
+			})
+		server.RegisterToolWithSchema("ok", "works", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				return &schema.CallToolResult{Content: []schema.CallToolResultContentElem{schema.TextContent{Type: "text", Text: "ok"}}}, nil
+			})
+		return nil
+	})
+	var crashes []*Crash
+	reporter := CrashReporterFunc(func(ctx context.Context, crash *Crash) {
+		mu.Lock()
+		defer mu.Unlock()
+		crashes = append(crashes, crash)
+	})
+	srv, err := New(WithNewHandler(newHandler), WithCrashReporter(reporter), WithToolHealth(2, 0),
+		WithTasks(task.New(task.WithPollInterval(5*time.Millisecond))))
+	if !assert.NoError(t, err) {
+		return
+	}
+	ctx := context.Background()
+	aClient := srv.InProcessClient(ctx, nil)
+
+	testCases := []struct {
+		name          string
+		tool          string
+		asTask        bool
+		expectErr     string
+		expectIsError string
+		expectRuns    int
+		expectCrash   int
+	}{
+		{name: "first panic", tool: "boom", expectErr: "tools/call panicked", expectRuns: 1, expectCrash: 1},
+		{name: "other tools keep working", tool: "ok", expectRuns: 1, expectCrash: 1},
+		{name: "task panic", tool: "boom", asTask: true, expectErr: "tools/call task panicked", expectRuns: 2, expectCrash: 2},
+		{name: "unhealthy tool rejected", tool: "boom", expectIsError: "unhealthy", expectRuns: 2, expectCrash: 2},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var result *schema.CallToolResult
+			var err error
+			if testCase.asTask {
+				var created *schema.CreateTaskResult
+				if created, err = aClient.CallToolAsTask(ctx, &schema.CallToolRequestParams{Name: testCase.tool}, nil); assert.NoError(t, err) {
+					result, err = aClient.GetTaskResult(ctx, created.Task.TaskId)
+				}
+			} else {
+				result, err = aClient.CallTool(ctx, &schema.CallToolRequestParams{Name: testCase.tool})
+			}
+			if testCase.expectErr != "" {
+				assert.ErrorContains(t, err, testCase.expectErr)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, testCase.expectIsError != "", result.IsError != nil && *result.IsError)
+				data, _ := json.Marshal(result.Content)
+				assert.Contains(t, string(data), testCase.expectIsError)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, testCase.expectRuns, runs)
+			if assert.Len(t, crashes, testCase.expectCrash) {
+				crash := crashes[len(crashes)-1]
+				assert.Equal(t, "boom", crash.Tool)
+				assert.Equal(t, "nil map", crash.Value)
+				assert.Contains(t, crash.Stack, "crash_test.go")
+			}
+		})
+	}
+	assert.Equal(t, []string{"boom"}, srv.UnhealthyTools())
+}
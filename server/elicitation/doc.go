@@ -0,0 +1,24 @@
+// Package elicitation provides typed form elicitation for server handlers.
+//
+// The requested schema is derived from the struct tags of T:
+//
+//	type Booking struct {
+//		Email  string `json:"email" title:"Email" format:"email"`
+//		Seats  int    `json:"seats" description:"Number of seats" default:"1"`
+//		Class  string `json:"class" choice:"economy" choice:"business"`
+//		Notes  string `json:"notes,omitempty"`
+//	}
+//
+//	result, err := elicitation.Elicit[Booking](ctx, server.Client, "Confirm your booking")
+//	if err != nil {
+//		return nil, jsonrpc.NewInternalError(err.Error(), nil)
+//	}
+//	if !result.Accepted() {
+//		// declined or cancelled
+//	}
+//	booking := result.Value
+//
+// Fields are required unless they are pointers, have `omitempty`, or are tagged `optional`
+// (`required:"true"` forces required). Only flat structs with string, boolean, integer and
+// number fields are supported, as mandated by the MCP elicitation schema.
+package elicitation
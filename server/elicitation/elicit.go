@@ -0,0 +1,169 @@
+package elicitation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/viant/jsonrpc"
+	pclient "github.com/viant/mcp-protocol/client"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+var (
+	// ErrNotSupported is returned when the client does not support elicitation.
+	ErrNotSupported = errors.New("elicitation: client does not support elicitation/create")
+	// ErrInvalidResponse is returned when accepted content does not match the requested schema.
+	ErrInvalidResponse = errors.New("elicitation: invalid response")
+)
+
+// Result represents the outcome of a typed elicitation.
+type Result[T any] struct {
+	Action schema.ElicitResultAction
+	// Value holds the decoded content when the user accepted.
+	Value *T
+}
+
+// Accepted returns true when the user submitted the form.
+func (r *Result[T]) Accepted() bool {
+	return r.Action == schema.ElicitResultActionAccept
+}
+
+// Declined returns true when the user explicitly declined.
+func (r *Result[T]) Declined() bool {
+	return r.Action == schema.ElicitResultActionDecline
+}
+
+// Cancelled returns true when the user dismissed the request without choosing.
+func (r *Result[T]) Cancelled() bool {
+	return r.Action == schema.ElicitResultActionCancel
+}
+
+// Elicit asks the user to fill a form derived from T and decodes the accepted content into T.
+// ErrNotSupported is returned when the client neither supports elicitation nor has a server fallback.
+func Elicit[T any](ctx context.Context, client pclient.Operations, message string) (*Result[T], error) {
+	if client == nil {
+		return nil, ErrNotSupported
+	}
+	fields, err := fieldsOf(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+	params := schema.ElicitRequestParams{Message: message, RequestedSchema: *requestedSchema(fields)}
+	request := &jsonrpc.TypedRequest[*schema.ElicitRequest]{Request: &schema.ElicitRequest{Method: schema.MethodElicitationCreate, Params: params}}
+	elicited, rpcErr := client.Elicit(ctx, request)
+	if rpcErr != nil && rpcErr.Code == jsonrpc.MethodNotFound {
+		return nil, ErrNotSupported
+	}
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if elicited == nil {
+		return nil, fmt.Errorf("%w: empty result", ErrInvalidResponse)
+	}
+	ret := &Result[T]{Action: elicited.Action}
+	switch elicited.Action {
+	case schema.ElicitResultActionDecline, schema.ElicitResultActionCancel:
+		return ret, nil
+	case schema.ElicitResultActionAccept:
+	default:
+		return nil, fmt.Errorf("%w: unknown action %q", ErrInvalidResponse, elicited.Action)
+	}
+	if ret.Value, err = decode[T](fields, elicited.Content); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// decode validates content against fields, applies defaults and decodes it into T.
+func decode[T any](fields []*field, content map[string]interface{}) (*T, error) {
+	values := make(map[string]interface{}, len(content))
+	for k, v := range content {
+		values[k] = v
+	}
+	var problems []string
+	for _, aField := range fields {
+		value, ok := values[aField.name]
+		if !ok || value == nil {
+			if aField.defaultVal != nil {
+				values[aField.name] = aField.defaultVal
+			} else if aField.required {
+				problems = append(problems, fmt.Sprintf("%v is required", aField.name))
+			}
+			continue
+		}
+		if problem := aField.validate(value); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, strings.Join(problems, "; "))
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	var ret T
+	if err = json.Unmarshal(data, &ret); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	return &ret, nil
+}
+
+// validate returns a problem description when value does not match the field type or enum.
+func (f *field) validate(value interface{}) string {
+	switch f.kind {
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Sprintf("%v: expected string, but had %T", f.name, value)
+		}
+		if len(f.enum) > 0 && !contains(f.enum, text) {
+			return fmt.Sprintf("%v: %q is not one of %v", f.name, text, strings.Join(f.enum, ", "))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Sprintf("%v: expected boolean, but had %T", f.name, value)
+		}
+	case "integer", "number":
+		number, ok := asNumber(value)
+		if !ok {
+			return fmt.Sprintf("%v: expected %v, but had %T", f.name, f.kind, value)
+		}
+		if f.kind == "integer" && number != math.Trunc(number) {
+			return fmt.Sprintf("%v: expected integer, but had %v", f.name, number)
+		}
+	}
+	return ""
+}
+
+func asNumber(value interface{}) (float64, bool) {
+	switch actual := value.(type) {
+	case json.Number:
+		number, err := actual.Float64()
+		return number, err == nil
+	}
+	rValue := reflect.ValueOf(value)
+	switch rValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rValue.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rValue.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rValue.Float(), true
+	}
+	return 0, false
+}
+
+func contains(values []string, candidate string) bool {
+	for _, value := range values {
+		if value == candidate {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package elicitation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+type booking struct {
+	Email string  `json:"email" title:"Email" format:"email"`
+	Seats int     `json:"seats" description:"Number of seats" default:"1"`
+	Class string  `json:"class" choice:"economy" choice:"business"`
+	Notes *string `json:"notes,omitempty"`
+}
+
+type fakeClient struct {
+	supported bool
+	result    *schema.ElicitResult
+	request   *schema.ElicitRequest
+}
+
+func (c *fakeClient) Notify(ctx context.Context, notification *jsonrpc.Notification) error {
+	return nil
+}
+func (c *fakeClient) NextRequestID() jsonrpc.RequestId                                  { return 1 }
+func (c *fakeClient) LastRequestID() jsonrpc.RequestId                                  { return 1 }
+func (c *fakeClient) Init(ctx context.Context, capabilities *schema.ClientCapabilities) {}
+func (c *fakeClient) Implements(method string) bool {
+	return c.supported && method == schema.MethodElicitationCreate
+}
+func (c *fakeClient) ListRoots(ctx context.Context, request *jsonrpc.TypedRequest[*schema.ListRootsRequest]) (*schema.ListRootsResult, *jsonrpc.Error) {
+	return nil, jsonrpc.NewMethodNotFound("roots", nil)
+}
+func (c *fakeClient) CreateMessage(ctx context.Context, request *jsonrpc.TypedRequest[*schema.CreateMessageRequest]) (*schema.CreateMessageResult, *jsonrpc.Error) {
+	return nil, jsonrpc.NewMethodNotFound("sampling", nil)
+}
+func (c *fakeClient) Elicit(ctx context.Context, request *jsonrpc.TypedRequest[*schema.ElicitRequest]) (*schema.ElicitResult, *jsonrpc.Error) {
+	if !c.supported {
+		return nil, jsonrpc.NewMethodNotFound("elicit", nil)
+	}
+	c.request = request.Request
+	return c.result, nil
+}
+
+func TestSchema(t *testing.T) {
+	actual, err := Schema[booking]()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, &schema.ElicitRequestParamsRequestedSchema{
+		Type: "object",
+		Properties: map[string]interface{}{
+			"email": map[string]interface{}{"type": "string", "title": "Email", "format": "email"},
+			"seats": map[string]interface{}{"type": "integer", "description": "Number of seats", "default": int64(1)},
+			"class": map[string]interface{}{"type": "string", "enum": []string{"economy", "business"}},
+			"notes": map[string]interface{}{"type": "string"},
+		},
+		Required: []string{"email", "seats", "class"},
+	}, actual)
+
+	_, err = Schema[struct {
+		Address struct{ City string } `json:"address"`
+	}]()
+	assert.Error(t, err)
+}
+
+func TestElicit(t *testing.T) {
+	notes := "window seat"
+	testCases := []struct {
+		name         string
+		client       *fakeClient
+		expectAction schema.ElicitResultAction
+		expectValue  *booking
+		expectErr    error
+	}{
+		{
+			name: "accepted",
+			client: &fakeClient{supported: true, result: &schema.ElicitResult{Action: schema.ElicitResultActionAccept,
+				Content: map[string]interface{}{"email": "ada@example.com", "seats": float64(2), "class": "business", "notes": notes}}},
+			expectAction: schema.ElicitResultActionAccept,
+			expectValue:  &booking{Email: "ada@example.com", Seats: 2, Class: "business", Notes: &notes},
+		},
+		{
+			name: "default applied",
+			client: &fakeClient{supported: true, result: &schema.ElicitResult{Action: schema.ElicitResultActionAccept,
+				Content: map[string]interface{}{"email": "ada@example.com", "class": "economy"}}},
+			expectAction: schema.ElicitResultActionAccept,
+			expectValue:  &booking{Email: "ada@example.com", Seats: 1, Class: "economy"},
+		},
+		{
+			name:         "declined",
+			client:       &fakeClient{supported: true, result: &schema.ElicitResult{Action: schema.ElicitResultActionDecline}},
+			expectAction: schema.ElicitResultActionDecline,
+		},
+		{
+			name:         "cancelled",
+			client:       &fakeClient{supported: true, result: &schema.ElicitResult{Action: schema.ElicitResultActionCancel}},
+			expectAction: schema.ElicitResultActionCancel,
+		},
+		{
+			name: "invalid enum and missing field",
+			client: &fakeClient{supported: true, result: &schema.ElicitResult{Action: schema.ElicitResultActionAccept,
+				Content: map[string]interface{}{"class": "first"}}},
+			expectErr: ErrInvalidResponse,
+		},
+		{
+			name:      "not supported",
+			client:    &fakeClient{},
+			expectErr: ErrNotSupported,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := Elicit[booking](context.Background(), testCase.client, "Confirm your booking")
+			if testCase.expectErr != nil {
+				assert.True(t, errors.Is(err, testCase.expectErr), err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, testCase.expectAction, result.Action)
+			assert.Equal(t, testCase.expectValue, result.Value)
+			assert.Equal(t, "Confirm your booking", testCase.client.request.Params.Message)
+			assert.Equal(t, []string{"email", "seats", "class"}, testCase.client.request.Params.RequestedSchema.Required)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package elicitation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	"github.com/viant/mcp/server"
+	"github.com/viant/mcp/server/namespace"
+	"github.com/viant/mcp/server/oob"
+)
+
+// Form is the out-of-band payload of a pending elicitation.
+type Form struct {
+	Message         string
+	RequestedSchema schema.ElicitRequestParamsRequestedSchema
+}
+
+// Notifier presents the elicitation message and the URL to open to the user.
+type Notifier func(ctx context.Context, message, url string) error
+
+// Fallback serves elicitation for clients without the elicitation capability: it creates an out-of-band
+// pending form, sends its URL to the user and blocks the tool until the form is submitted or times out.
+// Pending waiters are held in memory, so the form must be served by the process running the tool.
+type Fallback struct {
+	manager *oob.Manager[Form]
+	timeout time.Duration
+	notify  Notifier
+	mu      sync.Mutex
+	waiters map[string]chan *schema.ElicitResult
+}
+
+// NewFallback creates a fallback serving forms under baseURL, e.g. http://localhost:5000/elicitation/.
+func NewFallback(baseURL string, options ...FallbackOption) *Fallback {
+	baseURL = strings.TrimRight(baseURL, "/") + "/"
+	ret := &Fallback{
+		manager: &oob.Manager[Form]{
+			Provider:        namespace.NewProvider(nil),
+			Store:           oob.NewMemoryStore[Form](),
+			CallbackBuilder: func(id string) string { return baseURL + id },
+		},
+		timeout: 10 * time.Minute,
+		notify:  emitLink,
+		waiters: make(map[string]chan *schema.ElicitResult),
+	}
+	for _, option := range options {
+		option(ret)
+	}
+	return ret
+}
+
+// ErrLinkNotDelivered is returned by the default notifier when the form URL cannot reach the user while the tool waits.
+var ErrLinkNotDelivered = errors.New("elicitation: form link cannot be streamed without a tools/call progress token; use WithNotifier")
+
+// emitLink streams the message and URL as output of the running tool call.
+func emitLink(ctx context.Context, message, url string) error {
+	if !server.ContentStreamed(ctx) {
+		return ErrLinkNotDelivered
+	}
+	return server.EmitText(ctx, fmt.Sprintf("%v\n\nOpen %v to respond.", message, url))
+}
+
+// Elicit implements server.ElicitationFallback.
+func (f *Fallback) Elicit(ctx context.Context, params *schema.ElicitRequestParams) (*schema.ElicitResult, *jsonrpc.Error) {
+	if params.Mode == schema.ElicitRequestParamsModeUrl {
+		if err := f.notify(ctx, params.Message, params.Url); err != nil {
+			return nil, jsonrpc.NewInternalError(err.Error(), nil)
+		}
+		return &schema.ElicitResult{Action: schema.ElicitResultActionAccept}, nil
+	}
+	id, callbackURL, err := f.manager.Create(ctx, oob.Spec[Form]{
+		Kind:      "elicitation",
+		ElicitID:  params.ElicitationId,
+		ExpiresAt: time.Now().Add(f.timeout),
+		Data:      Form{Message: params.Message, RequestedSchema: params.RequestedSchema},
+	})
+	if err != nil {
+		return nil, jsonrpc.NewInternalError(fmt.Sprintf("failed to create elicitation form: %v", err), nil)
+	}
+	waiter := make(chan *schema.ElicitResult, 1)
+	f.mu.Lock()
+	f.waiters[id] = waiter
+	f.mu.Unlock()
+	if err = f.notify(ctx, params.Message, callbackURL); err != nil {
+		f.abandon(id)
+		return nil, jsonrpc.NewInternalError(err.Error(), nil)
+	}
+	timer := time.NewTimer(f.timeout)
+	defer timer.Stop()
+	select {
+	case result := <-waiter:
+		return result, nil
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	if !f.abandon(id) {
+		return <-waiter, nil // submitted concurrently with the timeout
+	}
+	return &schema.ElicitResult{Action: schema.ElicitResultActionCancel}, nil
+}
+
+// abandon removes the waiter and cancels the pending form; it returns false when the form was already submitted.
+func (f *Fallback) abandon(id string) bool {
+	f.mu.Lock()
+	_, ok := f.waiters[id]
+	delete(f.waiters, id)
+	f.mu.Unlock()
+	if ok {
+		_, _ = f.manager.Cancel(context.Background(), id)
+	}
+	return ok
+}
+
+// resolve completes the pending form and resumes the waiting tool; it returns false when nobody waits anymore.
+func (f *Fallback) resolve(ctx context.Context, id string, result *schema.ElicitResult) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	waiter, ok := f.waiters[id]
+	if !ok {
+		return false
+	}
+	delete(f.waiters, id)
+	_, _ = f.manager.Complete(ctx, id)
+	waiter <- result
+	return true
+}
+
+// ServeHTTP renders the pending form on GET and resumes the tool on POST.
+func (f *Fallback) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	oob.NamespaceFromPending(f.manager.Store, pendingID, f.serveForm).ServeHTTP(w, r)
+}
+
+func pendingID(r *http.Request) (string, error) {
+	path := strings.TrimRight(r.URL.Path, "/")
+	return path[strings.LastIndex(path, "/")+1:], nil
+}
+
+func (f *Fallback) serveForm(ctx context.Context, pending oob.Pending[Form], w http.ResponseWriter, r *http.Request) error {
+	if !pending.ExpiresAt.IsZero() && time.Now().After(pending.ExpiresAt) {
+		http.Error(w, "form expired", http.StatusGone)
+		return nil
+	}
+	switch r.Method {
+	case http.MethodGet:
+		return renderForm(w, http.StatusOK, &pending.Data, nil, "")
+	case http.MethodPost:
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return nil
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return nil
+	}
+	result := &schema.ElicitResult{Action: schema.ElicitResultActionDecline}
+	if r.PostForm.Get("action") != "decline" {
+		content, problems := parseForm(&pending.Data.RequestedSchema, r.PostForm)
+		if len(problems) > 0 {
+			return renderForm(w, http.StatusBadRequest, &pending.Data, r.PostForm, strings.Join(problems, "; "))
+		}
+		result = &schema.ElicitResult{Action: schema.ElicitResultActionAccept, Content: content}
+	}
+	if !f.resolve(ctx, pending.ID, result) {
+		http.Error(w, "form expired", http.StatusGone)
+		return nil
+	}
+	return renderDone(w, result.Action)
+}
@@ -0,0 +1,161 @@
+package elicitation
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+	"github.com/viant/mcp/client"
+	"github.com/viant/mcp/server"
+)
+
+func TestFallback_Elicit(t *testing.T) {
+	requested, err := Schema[booking]()
+	if !assert.NoError(t, err) {
+		return
+	}
+	testCases := []struct {
+		name          string
+		submit        url.Values
+		expectStatus  int
+		expectAction  schema.ElicitResultAction
+		expectContent map[string]interface{}
+	}{
+		{
+			name:          "accepted",
+			submit:        url.Values{"action": {"accept"}, "email": {"ada@example.com"}, "seats": {"3"}, "class": {"business"}},
+			expectStatus:  http.StatusOK,
+			expectAction:  schema.ElicitResultActionAccept,
+			expectContent: map[string]interface{}{"email": "ada@example.com", "seats": int64(3), "class": "business"},
+		},
+		{
+			name:         "declined",
+			submit:       url.Values{"action": {"decline"}},
+			expectStatus: http.StatusOK,
+			expectAction: schema.ElicitResultActionDecline,
+		},
+		{
+			name:         "invalid submission then timeout",
+			submit:       url.Values{"action": {"accept"}, "class": {"first"}},
+			expectStatus: http.StatusBadRequest,
+			expectAction: schema.ElicitResultActionCancel,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			links := make(chan string, 1)
+			var fallback *Fallback
+			httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { fallback.ServeHTTP(w, r) }))
+			defer httpServer.Close()
+			fallback = NewFallback(httpServer.URL+"/elicitation/", WithFormTimeout(200*time.Millisecond), WithNotifier(func(ctx context.Context, message, link string) error {
+				links <- link
+				return nil
+			}))
+
+			done := make(chan *schema.ElicitResult, 1)
+			go func() {
+				result, rpcErr := fallback.Elicit(context.Background(), &schema.ElicitRequestParams{Message: "Confirm your booking", RequestedSchema: *requested})
+				assert.Nil(t, rpcErr)
+				done <- result
+			}()
+			link := <-links
+
+			response, err := http.Get(link)
+			if !assert.NoError(t, err) {
+				return
+			}
+			page, _ := io.ReadAll(response.Body)
+			_ = response.Body.Close()
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+			assert.Contains(t, string(page), "Confirm your booking")
+			assert.Contains(t, string(page), `type="email"`)
+			assert.Contains(t, string(page), "<option>business</option>")
+
+			response, err = http.PostForm(link, testCase.submit)
+			if !assert.NoError(t, err) {
+				return
+			}
+			_ = response.Body.Close()
+			assert.Equal(t, testCase.expectStatus, response.StatusCode)
+
+			result := <-done
+			assert.Equal(t, testCase.expectAction, result.Action)
+			assert.Equal(t, testCase.expectContent, result.Content)
+
+			response, err = http.Get(link)
+			if assert.NoError(t, err) {
+				_ = response.Body.Close()
+				assert.Equal(t, http.StatusNotFound, response.StatusCode)
+			}
+		})
+	}
+}
+
+func TestFallback_DefaultNotifier(t *testing.T) {
+	var fallback *Fallback
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { fallback.ServeHTTP(w, r) }))
+	defer httpServer.Close()
+	fallback = NewFallback(httpServer.URL+"/elicitation/", WithFormTimeout(time.Minute))
+	newHandler := serverproto.WithDefaultHandler(context.Background(), func(handler *serverproto.DefaultHandler) error {
+		handler.RegisterToolWithSchema("book", "asks for a confirmation", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				result, rpcErr := handler.Client.Elicit(ctx, &jsonrpc.TypedRequest[*schema.ElicitRequest]{Request: &schema.ElicitRequest{
+					Params: schema.ElicitRequestParams{Message: "Confirm your booking", RequestedSchema: schema.ElicitRequestParamsRequestedSchema{Type: "object"}},
+				}})
+				if rpcErr != nil {
+					return nil, rpcErr
+				}
+				return &schema.CallToolResult{Content: []schema.CallToolResultContentElem{schema.TextContent{Type: "text", Text: string(result.Action)}}}, nil
+			})
+		return nil
+	})
+	srv, err := server.New(server.WithNewHandler(newHandler), server.WithElicitationFallback("/elicitation/", fallback))
+	if !assert.NoError(t, err) {
+		return
+	}
+	ctx := context.Background()
+
+	testCases := []struct {
+		name        string
+		listen      bool
+		expectText  string
+		expectError bool
+	}{
+		{name: "link streamed to listener", listen: true, expectText: "decline"},
+		{name: "no progress token", expectText: ErrLinkNotDelivered.Error(), expectError: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var options []client.RequestOption
+			if testCase.listen {
+				options = append(options, client.WithContentListener(func(ctx context.Context, content []schema.CallToolResultContentElem) {
+					data, _ := json.Marshal(content)
+					link := regexp.MustCompile(`http://[^ "\\]+`).FindString(string(data))
+					go func() {
+						response, err := http.PostForm(link, url.Values{"action": {"decline"}})
+						if assert.NoError(t, err) {
+							_ = response.Body.Close()
+						}
+					}()
+				}))
+			}
+			result, err := srv.InProcessClient(ctx, nil).CallTool(ctx, &schema.CallToolRequestParams{Name: "book"}, options...)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, testCase.expectError, result.IsError != nil && *result.IsError)
+			data, _ := json.Marshal(result.Content)
+			assert.Contains(t, string(data), testCase.expectText)
+		})
+	}
+}
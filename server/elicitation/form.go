@@ -0,0 +1,172 @@
+package elicitation
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/viant/mcp-protocol/schema"
+)
+
+var formTemplate = template.Must(template.New("form").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Input requested</title></head>
+<body>
+<p>{{.Message}}</p>
+{{if .Error}}<p style="color:#b00020">{{.Error}}</p>{{end}}
+<form method="post">
+{{range .Inputs}}<p>
+<label for="{{.Name}}">{{.Label}}{{if .Required}} *{{end}}</label><br>
+{{if .Options}}<select id="{{.Name}}" name="{{.Name}}">{{if not .Required}}<option value=""></option>{{end}}{{$value := .Value}}{{range .Options}}<option{{if eq . $value}} selected{{end}}>{{.}}</option>{{end}}</select>
+{{else if eq .Type "checkbox"}}<input type="checkbox" id="{{.Name}}" name="{{.Name}}" value="true"{{if eq .Value "true"}} checked{{end}}>
+{{else}}<input type="{{.Type}}" id="{{.Name}}" name="{{.Name}}" value="{{.Value}}"{{if eq .Type "number"}} step="any"{{end}}{{if .Required}} required{{end}}>
+{{end}}{{if .Description}}<br><small>{{.Description}}</small>{{end}}
+</p>
+{{end}}<button type="submit" name="action" value="accept">Submit</button>
+<button type="submit" name="action" value="decline" formnovalidate>Decline</button>
+</form>
+</body>
+</html>
+`))
+
+var doneTemplate = template.Must(template.New("done").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Done</title></head>
+<body><p>{{if eq . "accept"}}Thank you, your response was submitted.{{else}}The request was declined.{{end}} You can close this window.</p></body>
+</html>
+`))
+
+// input represents a rendered form input.
+type input struct {
+	Name        string
+	Label       string
+	Description string
+	Type        string
+	Options     []string
+	Value       string
+	Required    bool
+}
+
+func renderForm(w http.ResponseWriter, status int, form *Form, submitted url.Values, problem string) error {
+	requested := &form.RequestedSchema
+	var inputs []*input
+	for _, name := range propertyNames(requested) {
+		property := propertyOf(requested, name)
+		anInput := &input{Name: name, Label: name, Type: inputType(property), Options: stringsOf(property["enum"]), Required: contains(requested.Required, name)}
+		if title, ok := property["title"].(string); ok && title != "" {
+			anInput.Label = title
+		}
+		anInput.Description, _ = property["description"].(string)
+		if value, ok := property["default"]; ok && value != nil {
+			anInput.Value = fmt.Sprint(value)
+		}
+		if submitted != nil {
+			anInput.Value = submitted.Get(name)
+		}
+		inputs = append(inputs, anInput)
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	return formTemplate.Execute(w, map[string]interface{}{"Message": form.Message, "Error": problem, "Inputs": inputs})
+}
+
+func renderDone(w http.ResponseWriter, action schema.ElicitResultAction) error {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	return doneTemplate.Execute(w, string(action))
+}
+
+// parseForm converts submitted values into elicitation content typed after the requested schema.
+func parseForm(requested *schema.ElicitRequestParamsRequestedSchema, values url.Values) (map[string]interface{}, []string) {
+	content := map[string]interface{}{}
+	var problems []string
+	for _, name := range propertyNames(requested) {
+		property := propertyOf(requested, name)
+		kind, _ := property["type"].(string)
+		text := strings.TrimSpace(values.Get(name))
+		if kind == "boolean" {
+			content[name] = text == "true" || text == "on"
+			continue
+		}
+		if text == "" {
+			if contains(requested.Required, name) {
+				problems = append(problems, fmt.Sprintf("%v is required", name))
+			}
+			continue
+		}
+		switch kind {
+		case "integer":
+			value, err := strconv.ParseInt(text, 10, 64)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%v: expected integer", name))
+				continue
+			}
+			content[name] = value
+		case "number":
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%v: expected number", name))
+				continue
+			}
+			content[name] = value
+		default:
+			if options := stringsOf(property["enum"]); len(options) > 0 && !contains(options, text) {
+				problems = append(problems, fmt.Sprintf("%v: %q is not one of %v", name, text, strings.Join(options, ", ")))
+				continue
+			}
+			content[name] = text
+		}
+	}
+	return content, problems
+}
+
+func propertyNames(requested *schema.ElicitRequestParamsRequestedSchema) []string {
+	names := make([]string, 0, len(requested.Properties))
+	for name := range requested.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func propertyOf(requested *schema.ElicitRequestParamsRequestedSchema, name string) map[string]interface{} {
+	property, _ := requested.Properties[name].(map[string]interface{})
+	return property
+}
+
+func inputType(property map[string]interface{}) string {
+	switch property["type"] {
+	case "boolean":
+		return "checkbox"
+	case "integer", "number":
+		return "number"
+	}
+	switch property["format"] {
+	case "email":
+		return "email"
+	case "uri":
+		return "url"
+	case "date":
+		return "date"
+	case "date-time":
+		return "datetime-local"
+	}
+	return "text"
+}
+
+func stringsOf(value interface{}) []string {
+	switch actual := value.(type) {
+	case []string:
+		return actual
+	case []interface{}:
+		ret := make([]string, 0, len(actual))
+		for _, item := range actual {
+			ret = append(ret, fmt.Sprint(item))
+		}
+		return ret
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package elicitation
+
+import (
+	"time"
+
+	"github.com/viant/mcp/server/oob"
+)
+
+// FallbackOption represents a fallback option.
+type FallbackOption func(f *Fallback)
+
+// WithFormTimeout sets how long a tool waits for the form submission before the elicitation is cancelled.
+func WithFormTimeout(timeout time.Duration) FallbackOption {
+	return func(f *Fallback) {
+		f.timeout = timeout
+	}
+}
+
+// WithManager sets the OOB manager, e.g. to use a custom namespace provider or store; its CallbackBuilder
+// must return URLs served by the fallback.
+func WithManager(manager *oob.Manager[Form]) FallbackOption {
+	return func(f *Fallback) {
+		f.manager = manager
+	}
+}
+
+// WithNotifier sets how the form URL is presented to the user; by default it is streamed as tool output,
+// which requires a tools/call progress token (ErrLinkNotDelivered otherwise).
+func WithNotifier(notifier Notifier) FallbackOption {
+	return func(f *Fallback) {
+		f.notify = notifier
+	}
+}
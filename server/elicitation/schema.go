@@ -0,0 +1,147 @@
+package elicitation
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/viant/mcp-protocol/schema"
+)
+
+var choiceExpr = regexp.MustCompile(`choice:"([^"]+)"`)
+
+// field describes a struct field mapped to a requested schema property.
+type field struct {
+	name       string
+	kind       string
+	enum       []string
+	defaultVal interface{}
+	required   bool
+	property   map[string]interface{}
+}
+
+// Schema derives the elicitation requested schema from the struct tags of T.
+func Schema[T any]() (*schema.ElicitRequestParamsRequestedSchema, error) {
+	fields, err := fieldsOf(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+	return requestedSchema(fields), nil
+}
+
+func requestedSchema(fields []*field) *schema.ElicitRequestParamsRequestedSchema {
+	ret := &schema.ElicitRequestParamsRequestedSchema{Type: "object", Properties: map[string]interface{}{}}
+	for _, aField := range fields {
+		ret.Properties[aField.name] = aField.property
+		if aField.required {
+			ret.Required = append(ret.Required, aField.name)
+		}
+	}
+	return ret
+}
+
+func fieldsOf(t reflect.Type) ([]*field, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("elicitation: expected struct, but had %v", t.Kind())
+	}
+	var ret []*field
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+		jsonTag := structField.Tag.Get("json")
+		if jsonTag == "-" {
+			continue
+		}
+		name, omitempty := structField.Name, false
+		if jsonTag != "" {
+			parts := strings.Split(jsonTag, ",")
+			if parts[0] != "" {
+				name = parts[0]
+			}
+			for _, option := range parts[1:] {
+				omitempty = omitempty || option == "omitempty"
+			}
+		}
+		fieldType := structField.Type
+		isPtr := fieldType.Kind() == reflect.Ptr
+		if isPtr {
+			fieldType = fieldType.Elem()
+		}
+		kind, err := primitiveKind(fieldType)
+		if err != nil {
+			return nil, fmt.Errorf("elicitation: field %v: %w", structField.Name, err)
+		}
+		aField := &field{name: name, kind: kind}
+		tag := string(structField.Tag)
+		for _, match := range choiceExpr.FindAllStringSubmatch(tag, -1) {
+			aField.enum = append(aField.enum, match[1])
+		}
+		if value, ok := structField.Tag.Lookup("default"); ok {
+			if aField.defaultVal, err = parseDefault(kind, value); err != nil {
+				return nil, fmt.Errorf("elicitation: field %v default: %w", structField.Name, err)
+			}
+		}
+		requiredValue, hasRequired := structField.Tag.Lookup("required")
+		switch {
+		case hasRequired:
+			aField.required = requiredValue == "" || requiredValue == "true"
+		case strings.Contains(tag, "optional"):
+		default:
+			aField.required = !isPtr && !omitempty
+		}
+		aField.property = propertySchema(structField, aField)
+		ret = append(ret, aField)
+	}
+	return ret, nil
+}
+
+// propertySchema returns the requested schema property of a struct field.
+func propertySchema(structField reflect.StructField, aField *field) map[string]interface{} {
+	ret := map[string]interface{}{"type": aField.kind}
+	for _, key := range []string{"title", "description", "format"} {
+		if value := structField.Tag.Get(key); value != "" {
+			ret[key] = value
+		}
+	}
+	if len(aField.enum) > 0 {
+		ret["enum"] = aField.enum
+	}
+	if aField.defaultVal != nil {
+		ret["default"] = aField.defaultVal
+	}
+	return ret
+}
+
+func primitiveKind(t reflect.Type) (string, error) {
+	switch t.Kind() {
+	case reflect.String:
+		return "string", nil
+	case reflect.Bool:
+		return "boolean", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer", nil
+	case reflect.Float32, reflect.Float64:
+		return "number", nil
+	}
+	return "", fmt.Errorf("unsupported type %v; only string, boolean, integer and number are allowed", t)
+}
+
+func parseDefault(kind, value string) (interface{}, error) {
+	switch kind {
+	case "boolean":
+		return strconv.ParseBool(value)
+	case "integer":
+		return strconv.ParseInt(value, 10, 64)
+	case "number":
+		return strconv.ParseFloat(value, 64)
+	}
+	return value, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/viant/jsonrpc"
 	"github.com/viant/jsonrpc/transport"
@@ -12,6 +13,7 @@ import (
 	"github.com/viant/mcp-protocol/server"
 	"github.com/viant/mcp/internal/conv"
 	"github.com/viant/mcp/server/auth"
+	"github.com/viant/mcp/server/task"
 )
 
 // Handler represents handler
@@ -24,12 +26,21 @@ type Handler struct {
 	handler          server.Handler
 	authorizer       auth.JRPCAuthorizer //note that http level authorized is implemented as middleware
 	clientFeatures   map[string]bool
+	roots            *Roots
 	Initialized      bool
 	err              error
 }
 
 // Serve handles incoming JSON-RPC requests
 func (h *Handler) Serve(parent context.Context, request *jsonrpc.Request, response *jsonrpc.Response) {
+	ctx := parent
+	// Audit first so that rejected requests are recorded too; ctx gains the caller token below
+	if h.auditor != nil && h.auditor.Audits(request.Method) {
+		started := time.Now()
+		defer func() {
+			h.auditor.Record(contextWithMetaToken(ctx, request), request, response, started)
+		}()
+	}
 	// Check for valid JSONRPC version
 	if jsonrpc.Version != request.Jsonrpc {
 		response.Error = jsonrpc.NewInvalidRequest("invalid JSON-RPC version", nil)
@@ -39,20 +50,36 @@ func (h *Handler) Serve(parent context.Context, request *jsonrpc.Request, respon
 		response.Error = jsonrpc.NewInternalError(h.err.Error(), nil)
 		return
 	}
+	if h.oversize.take(request.Id) {
+		response.Error = jsonrpc.NewInvalidRequest("request exceeds size limit", nil)
+		return
+	}
 	switch request.Method {
 	case schema.MethodInitialize, schema.MethodPing:
 	case schema.MethodLoggingSetLevel:
+	case task.MethodGet, task.MethodResult, task.MethodCancel, task.MethodList:
+		if h.tasks == nil {
+			response.Error = jsonrpc.NewMethodNotFound(fmt.Sprintf("method: %v not found", request.Method), request.Params)
+			return
+		}
 	default:
-		if !h.handler.Implements(request.Method) {
+		if !h.implements(request.Method) {
 			response.Error = jsonrpc.NewMethodNotFound(fmt.Sprintf("method: %v not found", request.Method), request.Params)
 			return
 		}
 	}
 
+	if err := h.limits.checkRequest(request); err != nil {
+		response.Error = err
+		return
+	}
+
 	id := conv.AsInt(request.Id)
 
 	ctx, cancel := context.WithCancel(parent)
 	activeContext, ctx := newActiveContext(ctx, cancel, request)
+	ctx = context.WithValue(ctx, rootsKey, h.roots)
+	defer h.recoverRequest(ctx, request, response)
 
 	if h.authorizer != nil && request.Method != "" {
 		cred, err := h.authorizer(ctx, request, response)
@@ -70,17 +97,18 @@ func (h *Handler) Serve(parent context.Context, request *jsonrpc.Request, respon
 
 	h.activeContexts.Put(id, activeContext)
 	defer h.CancelOperation(id)
+	defer h.limits.checkResponse(response)
 
 	switch request.Method {
 	case schema.MethodInitialize:
 		result, err := h.Initialize(ctx, request)
-		h.setResponse(response, result, err)
+		h.setResponse(response, initializeResponse(result), err)
 	case schema.MethodPing:
 		result, err := h.Ping(ctx, request)
 		h.setResponse(response, result, err)
 	case schema.MethodResourcesList:
 		result, err := h.ListResources(ctx, request)
-		h.setResponse(response, result, err)
+		h.setResponse(response, h.visibleResources(ctx, result), err)
 	case schema.MethodResourcesTemplatesList:
 		result, err := h.ListResourceTemplates(ctx, request)
 		h.setResponse(response, result, err)
@@ -89,36 +117,20 @@ func (h *Handler) Serve(parent context.Context, request *jsonrpc.Request, respon
 		h.setResponse(response, result, err)
 	case schema.MethodPromptsList:
 		result, err := h.ListPrompts(ctx, request)
-		h.setResponse(response, result, err)
+		h.setResponse(response, h.visiblePrompts(ctx, result), err)
 	case schema.MethodPromptsGet:
 		result, err := h.GetPrompt(ctx, request)
 		h.setResponse(response, result, err)
 	case schema.MethodToolsList:
 		result, err := h.ListTools(ctx, request)
-		h.setResponse(response, result, err)
+		h.setResponse(response, h.visibleTools(ctx, result), err)
 	case schema.MethodToolsCall:
-		result, err := h.CallTool(ctx, request)
-		// For tool call errors, return a CallToolResult with isError flag instead of JSON-RPC error
-		if err != nil {
-			isErr := true
-			msg := err.Message
-			structured := map[string]interface{}{
-				"error":   true,
-				"code":    err.Code,
-				"message": err.Message,
-			}
-			if len(err.Data) > 0 {
-				structured["data"] = json.RawMessage(err.Data)
-			}
-			result = &schema.CallToolResult{
-				IsError:           &isErr,
-				StructuredContent: structured,
-				Content: []schema.CallToolResultContentElem{
-					schema.TextContent{Text: msg, Type: "text"},
-				},
-			}
-			err = nil
+		if h.tasks != nil && isTaskRequest(request) {
+			result, err := h.CallToolTask(ctx, request)
+			h.setResponse(response, result, err)
+			return
 		}
+		result, err := h.callTool(h.taskContext(ctx, request), request)
 		h.setResponse(response, result, err)
 	case schema.MethodComplete:
 		result, err := h.Complete(ctx, request)
@@ -132,11 +144,38 @@ func (h *Handler) Serve(parent context.Context, request *jsonrpc.Request, respon
 	case schema.MethodLoggingSetLevel:
 		result, err := h.SetLevel(ctx, request)
 		h.setResponse(response, result, err)
+	case task.MethodGet:
+		result, err := h.GetTask(ctx, request)
+		h.setResponse(response, result, err)
+	case task.MethodResult:
+		result, err := h.GetTaskResult(ctx, request)
+		h.setResponse(response, result, err)
+	case task.MethodCancel:
+		result, err := h.CancelTask(ctx, request)
+		h.setResponse(response, result, err)
+	case task.MethodList:
+		result, err := h.ListTasks(ctx, request)
+		h.setResponse(response, result, err)
 	default:
 		response.Error = jsonrpc.NewMethodNotFound(fmt.Sprintf("method: %v not found", request.Method), request.Params)
 	}
 }
 
+// implements returns true when the handler or a server-level registry serves method.
+func (h *Handler) implements(method string) bool {
+	switch method {
+	case schema.MethodComplete:
+		if h.completions != nil {
+			return true
+		}
+	case schema.MethodResourcesList, schema.MethodResourcesTemplatesList, schema.MethodResourcesRead:
+		if h.resourceRouter != nil {
+			return true
+		}
+	}
+	return h.handler.Implements(method)
+}
+
 func (h *Handler) setResponse(response *jsonrpc.Response, result interface{}, rpcError *jsonrpc.Error) {
 	if rpcError != nil {
 		response.Error = rpcError
@@ -150,13 +189,19 @@ func (h *Handler) setResponse(response *jsonrpc.Response, result interface{}, rp
 
 // OnNotification handles incoming JSON-RPC notifications
 func (h *Handler) OnNotification(ctx context.Context, notification *jsonrpc.Notification) {
+	defer h.recoverNotification(ctx, notification)
 	// Handle notifications if needed
 	switch notification.Method {
-	case schema.MethodNotificationCancel:
+	case schema.MethodNotificationCancel, schema.MethodNotificationCanceled:
 		h.Cancel(ctx, notification)
 	case schema.MethodNotificationInitialized:
 		h.Initialized = true
+		if h.clientInitialize != nil && h.clientInitialize.Capabilities.Roots != nil {
+			go h.roots.prefetch()
+		}
 		return
+	case MethodNotificationRootsListChanged:
+		h.roots.Invalidate()
 	}
 	h.handler.OnNotification(ctx, notification)
 }
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// loopbackHosts are the Host names allowed by default when the server binds to a loopback address.
+var loopbackHosts = []string{"localhost", "127.0.0.1", "::1"}
+
+// hostValidationMiddleware protects against DNS rebinding by rejecting requests whose Host header names a
+// host outside the allowlist with 421 Misdirected Request, and requests without a Host header, which cannot be
+// attributed to any allowed host, with 403 Forbidden. Entries are host names or IPs matching any port,
+// host:port pairs, wildcard subdomains (*.example.com) or "*" for any host.
+func hostValidationMiddleware(allowed []string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch host, _ := splitHost(r.Host); {
+			case slices.Contains(allowed, "*"):
+			case host == "":
+				http.Error(w, "host header required", http.StatusForbidden)
+				return
+			case !hostAllowed(allowed, r.Host):
+				http.Error(w, "host not allowed", http.StatusMisdirectedRequest)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// allowedHostsFor returns the configured allowlist, or loopback names when addr binds to a loopback address.
+// A nil result disables Host validation.
+func (s *Server) allowedHostsFor(addr string) []string {
+	if s.allowedHosts != nil {
+		return s.allowedHosts
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	if host == "localhost" {
+		return loopbackHosts
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return loopbackHosts
+	}
+	return nil
+}
+
+func hostAllowed(allowed []string, hostPort string) bool {
+	host, port := splitHost(hostPort)
+	if host == "" {
+		return false
+	}
+	for _, candidate := range allowed {
+		if candidate == "*" {
+			return true
+		}
+		candidateHost, candidatePort := splitHost(candidate)
+		if candidatePort != "" && candidatePort != port {
+			continue
+		}
+		if suffix, ok := strings.CutPrefix(candidateHost, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+			continue
+		}
+		if candidateHost == host {
+			return true
+		}
+	}
+	return false
+}
+
+// splitHost returns the lower-cased host without brackets or trailing dot, and the port.
+func splitHost(hostPort string) (string, string) {
+	host, port := hostPort, ""
+	switch {
+	case strings.HasPrefix(hostPort, "["):
+		if end := strings.Index(hostPort, "]"); end != -1 {
+			host, port = hostPort[1:end], strings.TrimPrefix(hostPort[end+1:], ":")
+		}
+	case strings.Count(hostPort, ":") == 1:
+		host, port, _ = strings.Cut(hostPort, ":")
+	}
+	return strings.ToLower(strings.TrimSuffix(host, ".")), port
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	serverproto "github.com/viant/mcp-protocol/server"
+)
+
+func TestServer_AllowedHosts(t *testing.T) {
+	newHandler := serverproto.WithDefaultHandler(context.Background())
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+	testCases := []struct {
+		name         string
+		addr         string
+		options      []Option
+		host         string
+		expectStatus int
+	}{
+		{name: "loopback default localhost", addr: "127.0.0.1:5000", host: "localhost:5000", expectStatus: http.StatusOK},
+		{name: "loopback default ipv6", addr: "[::1]:5000", host: "[::1]:5000", expectStatus: http.StatusOK},
+		{name: "loopback default rebinding", addr: "127.0.0.1:5000", host: "attacker.test:5000", expectStatus: http.StatusMisdirectedRequest},
+		{name: "all interfaces not validated", addr: ":5000", host: "attacker.test", expectStatus: http.StatusOK},
+		{name: "allowlist wildcard subdomain", addr: ":5000", options: []Option{WithAllowedHosts("*.example.com")}, host: "mcp.example.com", expectStatus: http.StatusOK},
+		{name: "allowlist port mismatch", addr: ":5000", options: []Option{WithAllowedHosts("mcp.example.com:443")}, host: "mcp.example.com:8443", expectStatus: http.StatusMisdirectedRequest},
+		{name: "allowlist rejects other host", addr: ":5000", options: []Option{WithAllowedHosts("mcp.example.com")}, host: "attacker.test", expectStatus: http.StatusMisdirectedRequest},
+		{name: "missing host forbidden", addr: "127.0.0.1:5000", host: "", expectStatus: http.StatusForbidden},
+		{name: "missing host with wildcard", addr: "127.0.0.1:5000", options: []Option{WithAllowedHosts("*")}, host: "", expectStatus: http.StatusOK},
+		{name: "wildcard disables validation", addr: "127.0.0.1:5000", options: []Option{WithAllowedHosts("*")}, host: "attacker.test", expectStatus: http.StatusOK},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, err := New(append([]Option{WithNewHandler(newHandler), WithCustomHTTPHandler("/ping", ok)}, tc.options...)...)
+			if !assert.NoError(t, err) {
+				return
+			}
+			request := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			request.Host = tc.host
+			recorder := httptest.NewRecorder()
+			srv.HTTP(context.Background(), tc.addr).Handler.ServeHTTP(recorder, request)
+			assert.EqualValues(t, tc.expectStatus, recorder.Code)
+		})
+	}
+}
@@ -6,10 +6,8 @@ import (
 	"time"
 
 	"fmt"
-	streamauth "github.com/viant/jsonrpc/transport/server/auth"
 	"github.com/viant/jsonrpc/transport/server/http/sse"
 	"github.com/viant/jsonrpc/transport/server/http/streamable"
-	mcpauth "github.com/viant/mcp/server/auth"
 	"os"
 	"strings"
 	"sync"
@@ -52,15 +50,12 @@ func (s *Server) HTTP(_ context.Context, addr string) *http.Server {
 		s.streamableURI = "/mcp"
 	}
 
-	// Default in-memory BFF AuthStore (dev-friendly). Replace via server options for production.
-	memAuth := streamauth.NewMemoryStore(30*time.Minute, 24*time.Hour, 2*time.Minute)
-	mcpauth.SetDefaultBFFAuthStore(memAuth)
-	mcpauth.SetDefaultBFFAuthCookieName("BFF-Auth-Session")
+	// BFF auth grant store and cookie are kept per server and shared with bound authorizers
+	authStore := s.bffAuth.bind()
+	cookie := s.bffAuth.cookie.Cookie("")
 	if serverDebug() {
 		fmt.Printf("[mcp/server] HTTP addr=%s sseURI=%s msgURI=%s streamURI=%s\n", addr, s.sseURI, s.sseMessageURI, s.streamableURI)
-	}
-	if serverDebug() {
-		fmt.Printf("[mcp/server] BFF auth: memStore=yes authCookie=BFF-Auth-Session rehydrate=true\n")
+		fmt.Printf("[mcp/server] BFF auth: store=%T authCookie=%s secure=%v rehydrate=true\n", authStore, cookie.Name, cookie.Secure)
 	}
 
 	// SSE and Streamable handlers with configured URIs
@@ -70,46 +65,63 @@ func (s *Server) HTTP(_ context.Context, addr string) *http.Server {
 		sse.WithMessageURI(s.sseMessageURI),
 		sse.WithKeepAliveInterval(2*time.Second),
 		// Enable auth cookie and rehydrate from it
-		sse.WithAuthStore(memAuth),
-		sse.WithBFFAuthCookie(&sse.BFFAuthCookie{Name: "BFF-Auth-Session", HttpOnly: true}),
+		sse.WithAuthStore(authStore),
+		sse.WithBFFAuthCookie(&sse.BFFAuthCookie{Name: cookie.Name, Path: cookie.Path, Domain: cookie.Domain, Secure: cookie.Secure, HttpOnly: true, SameSite: cookie.SameSite, MaxAge: cookie.MaxAge}),
 		sse.WithRehydrateOnHandshake(true),
 	)
 	s.streamingHandler = streamable.New(s.NewHandler,
 		streamable.WithURI(s.streamableURI),
 		streamable.WithKeepAliveInterval(2*time.Second),
 		// Enable auth cookie and rehydrate from it
-		streamable.WithAuthStore(memAuth),
-		streamable.WithBFFAuthCookie(&streamable.BFFAuthCookie{Name: "BFF-Auth-Session", HttpOnly: true}),
+		streamable.WithAuthStore(authStore),
+		streamable.WithBFFAuthCookie(&streamable.BFFAuthCookie{Name: cookie.Name, Path: cookie.Path, Domain: cookie.Domain, Secure: cookie.Secure, HttpOnly: true, SameSite: cookie.SameSite, MaxAge: cookie.MaxAge}),
 		streamable.WithRehydrateOnHandshake(true),
 	)
 	mux := http.NewServeMux()
 	if len(s.customHTTPHandlers) > 0 {
 		for path, handler := range s.customHTTPHandlers {
-			mux.Handle(path, handler)
+			mux.Handle(path, ChainMiddlewareHandlers(handler, s.corsMiddlewares(path, nil, false)...))
 		}
 	}
 	if s.protectedResourcesHandler != nil {
-		mux.Handle("/.well-known/oauth-protected-resource", s.protectedResourcesHandler)
+		const metadataURI = "/.well-known/oauth-protected-resource"
+		mux.Handle(metadataURI, ChainMiddlewareHandlers(s.protectedResourcesHandler, s.corsMiddlewares(metadataURI, metadataCors(), false)...))
 	}
 	var middlewareHandlers []Middleware
+	if s.limits != nil {
+		middlewareHandlers = append(middlewareHandlers, bodyLimitMiddleware(s.limits.MaxBodyBytes))
+	}
 	if s.authorizer != nil {
 		middlewareHandlers = append(middlewareHandlers, s.authorizer)
 	}
 	// Validate MCP-Protocol-Version and set response header
 	middlewareHandlers = append(middlewareHandlers, protocolVersionMiddleware())
-	middlewareHandlers = append(middlewareHandlers, s.corsHandler)
-	// Validate Origin on all requests (uses configured CORS allowlist)
-	if s.corsConfig != nil {
-		middlewareHandlers = append(middlewareHandlers, originValidationMiddleware(s.corsConfig.AllowOrigins))
-	}
-	// Wrap handlers with middleware
-	sseChain := ChainMiddlewareHandlers(s.sseHandler, middlewareHandlers...)
-	streamChain := ChainMiddlewareHandlers(s.streamingHandler, middlewareHandlers...)
-
-	// Mount handlers at their base URIs
-	mux.Handle(s.sseURI, sseChain)
-	mux.Handle(s.sseMessageURI, sseChain)
-	mux.Handle(s.streamableURI, streamChain)
+	// Mount handlers at their base URIs; CORS is outermost so preflights are answered before auth and limits
+	mcpChain := func(uri string, handler http.Handler) http.Handler {
+		return ChainMiddlewareHandlers(handler, append(s.corsMiddlewares(uri, s.corsConfig, true), middlewareHandlers...)...)
+	}
+	mux.Handle(s.sseURI, mcpChain(s.sseURI, s.sseHandler))
+	mux.Handle(s.sseMessageURI, mcpChain(s.sseMessageURI, s.sseHandler))
+	mux.Handle(s.streamableURI, mcpChain(s.streamableURI, s.streamingHandler))
+	if s.catalogURI != "" {
+		catalogChain := ChainMiddlewareHandlers(http.HandlerFunc(s.catalogHandler), s.corsMiddlewares(s.catalogURI, s.corsConfig, false)...)
+		mux.Handle(s.catalogURI, catalogChain)
+		mux.Handle(s.catalogURI+"/openapi.json", catalogChain)
+	}
+	if s.restEnabled {
+		var restMiddlewares []Middleware
+		if s.authorizer != nil {
+			restMiddlewares = append(restMiddlewares, s.authorizer)
+		}
+		restPreflight := s.corsMiddlewares(s.restPrefix, s.corsConfig, true)
+		if s.limits != nil {
+			restPreflight = append(restPreflight, bodyLimitMiddleware(s.limits.MaxBodyBytes))
+		}
+		restChain := ChainMiddlewareHandlers(newRESTHandler(s, s.restPrefix, restMiddlewares...), restPreflight...)
+		mux.Handle(s.restPrefix+"/tools/", restChain)
+		mux.Handle(s.restPrefix+"/resources", restChain)
+		mux.Handle(s.restPrefix+"/prompts/", restChain)
+	}
 
 	// Optional root redirect to the active transport base
 	if s.rootRedirect {
@@ -121,13 +133,34 @@ func (s *Server) HTTP(_ context.Context, addr string) *http.Server {
 			http.Redirect(w, r, target, http.StatusTemporaryRedirect)
 		})
 	}
+	var handler http.Handler = mux
+	if allowedHosts := s.allowedHostsFor(addr); allowedHosts != nil {
+		handler = hostValidationMiddleware(allowedHosts)(mux)
+	}
 	server := &http.Server{
 		Addr:    addr,
-		Handler: mux,
+		Handler: handler,
 	}
 	return server
 }
 
+// corsMiddlewares returns the CORS middlewares of the route mounted at path, using the policy set with
+// WithRouteCORS or fallback; validateOrigin also rejects requests from origins outside the policy.
+func (s *Server) corsMiddlewares(path string, fallback *Cors, validateOrigin bool) []Middleware {
+	policy := fallback
+	if cors, ok := s.routeCors[path]; ok {
+		policy = cors
+	}
+	if policy == nil {
+		return nil
+	}
+	ret := []Middleware{(&corsHandler{Cors: policy}).Middleware}
+	if validateOrigin {
+		ret = append(ret, originValidationMiddleware(policy.AllowOrigins))
+	}
+	return ret
+}
+
 var srvDbg struct {
 	once sync.Once
 	v    bool
@@ -25,6 +25,15 @@ func (h *Handler) Initialize(ctx context.Context, request *jsonrpc.Request) (*sc
 	}
 
 	h.handler.Initialize(ctx, h.clientInitialize, &result)
+	if h.completions != nil && result.Capabilities.Completions == nil {
+		result.Capabilities.Completions = map[string]interface{}{}
+	}
+	if h.resourceRouter != nil && result.Capabilities.Resources == nil {
+		result.Capabilities.Resources = &schema.ServerCapabilitiesResources{}
+	}
+	if h.tasks != nil && result.Capabilities.Tasks == nil {
+		result.Capabilities.Tasks = taskCapabilities()
+	}
 	return &result, nil
 }
 
@@ -37,3 +46,27 @@ func (h *Handler) Ping(ctx context.Context, request *jsonrpc.Request) (*schema.P
 	result := schema.PingResult{}
 	return &result, nil
 }
+
+// initializeResult encodes InitializeResult keeping an empty completions capability as {}.
+type initializeResult struct {
+	*schema.InitializeResult
+	Capabilities serverCapabilities `json:"capabilities"`
+}
+
+// serverCapabilities replaces the omitempty completions map with a pointer, encoded as {} when set but empty.
+type serverCapabilities struct {
+	schema.ServerCapabilities
+	Completions *map[string]interface{} `json:"completions,omitempty"`
+}
+
+// initializeResponse returns the result to encode.
+func initializeResponse(result *schema.InitializeResult) interface{} {
+	if result == nil {
+		return result
+	}
+	ret := &initializeResult{InitializeResult: result, Capabilities: serverCapabilities{ServerCapabilities: result.Capabilities}}
+	if result.Capabilities.Completions != nil {
+		ret.Capabilities.Completions = &result.Capabilities.Completions
+	}
+	return ret
+}
@@ -0,0 +1,14 @@
+// Package inprocess provides an in-memory, full-duplex JSON-RPC transport that
+// connects an MCP client to a server handler without sockets.
+//
+// Client requests and notifications are delivered to the server handler, and
+// server-to-client requests (elicitation, sampling, roots) and notifications
+// (logging, progress, resource updates) are delivered to the client handler.
+// Messages are copied through JSON so that both peers observe the same values
+// as over a remote connection.
+//
+// Usage:
+//
+//	aTransport := inprocess.New(ctx, srv.NewHandler, client.NewHandler(myClientHandler))
+//	aClient := client.New("app", "1.0", aTransport, client.WithClientHandler(myClientHandler))
+package inprocess
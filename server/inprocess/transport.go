@@ -0,0 +1,178 @@
+package inprocess
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/jsonrpc/transport"
+	"github.com/viant/jsonrpc/transport/server/base"
+	"github.com/viant/mcp-protocol/authorization"
+	authtransport "github.com/viant/mcp/client/auth/transport"
+)
+
+// ErrClosed is returned when a message is sent over a closed transport.
+var ErrClosed = errors.New("in-process transport is closed")
+
+// Transport is the client side of an in-process connection.
+type Transport struct {
+	session *base.Session
+	server  transport.Handler
+	peer    *peer
+	seq     uint64
+	closed  int32
+}
+
+// New creates a client transport connected to a server handler created by newHandler.
+// clientHandler serves server-to-client requests and notifications; it may be nil.
+func New(ctx context.Context, newHandler transport.NewHandler, clientHandler transport.Handler) *Transport {
+	now := time.Now()
+	session := &base.Session{Id: uuid.NewString(), CreatedAt: now, LastSeen: now}
+	ret := &Transport{session: session}
+	ret.peer = &peer{transport: ret, handler: clientHandler}
+	ret.server = newHandler(context.WithValue(ctx, jsonrpc.SessionKey, session), ret.peer)
+	return ret
+}
+
+// SessionID returns the in-process session id.
+func (t *Transport) SessionID() string {
+	return t.session.Id
+}
+
+// Send delivers a request to the server handler and returns its response.
+func (t *Transport) Send(ctx context.Context, request *jsonrpc.Request) (*jsonrpc.Response, error) {
+	if t.isClosed() {
+		return nil, ErrClosed
+	}
+	if request.Id == nil {
+		request.Id = t.NextRequestID()
+	}
+	delivered := &jsonrpc.Request{}
+	if err := copyMessage(request, delivered); err != nil {
+		return nil, err
+	}
+	response := &jsonrpc.Response{Id: delivered.Id, Jsonrpc: delivered.Jsonrpc}
+	t.server.Serve(t.serverContext(ctx), delivered, response)
+	ret := &jsonrpc.Response{}
+	if err := copyMessage(response, ret); err != nil {
+		return nil, err
+	}
+	ret.Id = request.Id
+	return ret, nil
+}
+
+// Notify delivers a notification to the server handler.
+func (t *Transport) Notify(ctx context.Context, notification *jsonrpc.Notification) error {
+	if t.isClosed() {
+		return ErrClosed
+	}
+	delivered := copyNotification(notification)
+	t.server.OnNotification(t.serverContext(ctx), delivered)
+	return nil
+}
+
+// NextRequestID returns the next client request id.
+func (t *Transport) NextRequestID() jsonrpc.RequestId {
+	return int(atomic.AddUint64(&t.seq, 1))
+}
+
+// LastRequestID returns the last client request id.
+func (t *Transport) LastRequestID() jsonrpc.RequestId {
+	return int(atomic.LoadUint64(&t.seq))
+}
+
+// Close disconnects both peers.
+func (t *Transport) Close() error {
+	atomic.StoreInt32(&t.closed, 1)
+	return nil
+}
+
+func (t *Transport) isClosed() bool {
+	return atomic.LoadInt32(&t.closed) == 1
+}
+
+// serverContext mirrors what the HTTP transport exposes to server handlers: the session and the caller token.
+func (t *Transport) serverContext(ctx context.Context) context.Context {
+	t.session.Touch()
+	ctx = context.WithValue(ctx, jsonrpc.SessionKey, t.session)
+	if token, ok := ctx.Value(authtransport.ContextAuthTokenKey).(string); ok && token != "" {
+		ctx = context.WithValue(ctx, authorization.TokenKey, &authorization.Token{Token: token})
+	}
+	return ctx
+}
+
+// peer is the server side of an in-process connection.
+type peer struct {
+	transport *Transport
+	handler   transport.Handler
+}
+
+// Send delivers a server-to-client request to the client handler.
+func (p *peer) Send(ctx context.Context, request *jsonrpc.Request) (*jsonrpc.Response, error) {
+	if p.transport.isClosed() {
+		return nil, ErrClosed
+	}
+	if request.Id == nil {
+		request.Id = p.NextRequestID()
+	}
+	if p.handler == nil {
+		return &jsonrpc.Response{Id: request.Id, Jsonrpc: jsonrpc.Version, Error: jsonrpc.NewMethodNotFound(fmt.Sprintf("method %s not found", request.Method), nil)}, nil
+	}
+	delivered := &jsonrpc.Request{}
+	if err := copyMessage(request, delivered); err != nil {
+		return nil, err
+	}
+	response := &jsonrpc.Response{Id: delivered.Id, Jsonrpc: delivered.Jsonrpc}
+	p.handler.Serve(ctx, delivered, response)
+	ret := &jsonrpc.Response{}
+	if err := copyMessage(response, ret); err != nil {
+		return nil, err
+	}
+	ret.Id = request.Id
+	return ret, nil
+}
+
+// Notify delivers a server notification to the client handler.
+func (p *peer) Notify(ctx context.Context, notification *jsonrpc.Notification) error {
+	if p.transport.isClosed() {
+		return ErrClosed
+	}
+	if p.handler == nil {
+		return nil
+	}
+	delivered := copyNotification(notification)
+	p.handler.OnNotification(ctx, delivered)
+	return nil
+}
+
+// NextRequestID returns the next server-to-client request id.
+func (p *peer) NextRequestID() jsonrpc.RequestId {
+	return p.transport.session.NextRequestID()
+}
+
+// LastRequestID returns the last server-to-client request id.
+func (p *peer) LastRequestID() jsonrpc.RequestId {
+	return p.transport.session.LastRequestID()
+}
+
+// copyNotification copies notification; params are copied directly as Notification.UnmarshalJSON does not restore them.
+func copyNotification(notification *jsonrpc.Notification) *jsonrpc.Notification {
+	return &jsonrpc.Notification{
+		Jsonrpc: notification.Jsonrpc,
+		Method:  notification.Method,
+		Params:  append(json.RawMessage(nil), notification.Params...),
+	}
+}
+
+func copyMessage(source, dest interface{}) error {
+	data, err := json.Marshal(source)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dest)
+}
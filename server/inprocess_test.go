@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+)
+
+type inProcessClientHandler struct {
+	mu            sync.Mutex
+	seq           int
+	notifications []string
+}
+
+func (h *inProcessClientHandler) Notify(ctx context.Context, n *jsonrpc.Notification) error {
+	return nil
+}
+func (h *inProcessClientHandler) NextRequestID() jsonrpc.RequestId                       { h.seq++; return h.seq }
+func (h *inProcessClientHandler) LastRequestID() jsonrpc.RequestId                       { return h.seq }
+func (h *inProcessClientHandler) Init(ctx context.Context, _ *schema.ClientCapabilities) {}
+func (h *inProcessClientHandler) Implements(method string) bool {
+	return method == schema.MethodElicitationCreate || method == schema.MethodRootsList
+}
+
+func (h *inProcessClientHandler) OnNotification(ctx context.Context, notification *jsonrpc.Notification) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.notifications = append(h.notifications, notification.Method)
+}
+
+func (h *inProcessClientHandler) ListRoots(ctx context.Context, request *jsonrpc.TypedRequest[*schema.ListRootsRequest]) (*schema.ListRootsResult, *jsonrpc.Error) {
+	return &schema.ListRootsResult{Roots: []schema.Root{{Uri: "file:///workspace"}}}, nil
+}
+
+func (h *inProcessClientHandler) CreateMessage(ctx context.Context, request *jsonrpc.TypedRequest[*schema.CreateMessageRequest]) (*schema.CreateMessageResult, *jsonrpc.Error) {
+	return nil, jsonrpc.NewMethodNotFound("sampling not supported", nil)
+}
+
+func (h *inProcessClientHandler) Elicit(ctx context.Context, request *jsonrpc.TypedRequest[*schema.ElicitRequest]) (*schema.ElicitResult, *jsonrpc.Error) {
+	return &schema.ElicitResult{Action: schema.ElicitResultActionAccept, Content: map[string]interface{}{"name": "Ada"}}, nil
+}
+
+func TestServer_InProcessClient(t *testing.T) {
+	newHandler := serverproto.WithDefaultHandler(context.Background(), func(server *serverproto.DefaultHandler) error {
+		server.RegisterToolWithSchema("greet", "greets the elicited user", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				_ = server.Logger.Info(ctx, "greeting")
+				roots, rErr := server.Client.ListRoots(ctx, &jsonrpc.TypedRequest[*schema.ListRootsRequest]{Request: &schema.ListRootsRequest{Params: &schema.ListRootsRequestParams{}}})
+				if rErr != nil {
+					return nil, rErr
+				}
+				elicited, eErr := server.Client.Elicit(ctx, &jsonrpc.TypedRequest[*schema.ElicitRequest]{Request: &schema.ElicitRequest{Params: schema.ElicitRequestParams{Message: "name?"}}})
+				if eErr != nil {
+					return nil, eErr
+				}
+				text := "hello " + elicited.Content["name"].(string) + " in " + roots.Roots[0].Uri
+				return &schema.CallToolResult{Content: []schema.CallToolResultContentElem{schema.TextContent{Type: "text", Text: text}}}, nil
+			})
+		return nil
+	})
+	srv, err := New(WithNewHandler(newHandler))
+	if !assert.NoError(t, err) {
+		return
+	}
+	ctx := context.Background()
+
+	testCases := []struct {
+		name          string
+		handler       *inProcessClientHandler
+		expectText    string
+		expectIsError bool
+	}{
+		{name: "back-calls served by client handler", handler: &inProcessClientHandler{}, expectText: "hello Ada in file:///workspace"},
+		{name: "no client handler", expectIsError: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var aClient = srv.InProcessClient(ctx, nil)
+			if testCase.handler != nil {
+				aClient = srv.InProcessClient(ctx, testCase.handler)
+			}
+			_, err := aClient.Initialize(ctx)
+			if !assert.NoError(t, err) {
+				return
+			}
+			_, err = aClient.SetLevel(ctx, &schema.SetLevelRequestParams{Level: schema.LoggingLevelDebug})
+			assert.NoError(t, err)
+			result, err := aClient.CallTool(ctx, &schema.CallToolRequestParams{Name: "greet"})
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, testCase.expectIsError, result.IsError != nil && *result.IsError)
+			if testCase.expectText != "" {
+				data, _ := json.Marshal(result.Content)
+				assert.Contains(t, string(data), testCase.expectText)
+				assert.Contains(t, testCase.handler.notifications, schema.MethodNotificationMessage)
+			}
+		})
+	}
+}
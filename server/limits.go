@@ -0,0 +1,139 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+// Limits bounds the size and shape of incoming requests and outgoing responses; zero disables a limit.
+type Limits struct {
+	// MaxBodyBytes limits the HTTP request body; larger bodies are rejected with 413.
+	MaxBodyBytes int64
+	// MaxParamsBytes limits the raw JSON-RPC params of a request on any transport.
+	MaxParamsBytes int
+	// MaxDepth limits the JSON nesting depth of request params.
+	MaxDepth int
+	// MaxArguments limits the number of tools/call arguments.
+	MaxArguments int
+	// MaxResponseBytes limits the encoded result of a response.
+	MaxResponseBytes int
+}
+
+// DefaultLimits returns recommended limits for servers exposed to untrusted clients; pass them to WithLimits
+// to enable them, no limit applies otherwise.
+func DefaultLimits() *Limits {
+	return &Limits{
+		MaxBodyBytes:   10 << 20,
+		MaxParamsBytes: 10 << 20,
+		MaxDepth:       100,
+	}
+}
+
+// checkRequest validates request params against the limits.
+func (l *Limits) checkRequest(request *jsonrpc.Request) *jsonrpc.Error {
+	if l == nil {
+		return nil
+	}
+	if l.MaxParamsBytes > 0 && len(request.Params) > l.MaxParamsBytes {
+		return jsonrpc.NewInvalidRequest(fmt.Sprintf("request params too large: %v bytes exceeds limit of %v", len(request.Params), l.MaxParamsBytes), nil)
+	}
+	if l.MaxDepth > 0 && jsonDepthExceeds(request.Params, l.MaxDepth) {
+		return jsonrpc.NewInvalidRequest(fmt.Sprintf("request params nested deeper than %v levels", l.MaxDepth), nil)
+	}
+	if l.MaxArguments > 0 && request.Method == schema.MethodToolsCall {
+		params := &struct {
+			Arguments map[string]json.RawMessage `json:"arguments"`
+		}{}
+		if err := json.Unmarshal(request.Params, params); err == nil && len(params.Arguments) > l.MaxArguments {
+			return jsonrpc.NewInvalidParamsError(fmt.Sprintf("too many arguments: %v exceeds limit of %v", len(params.Arguments), l.MaxArguments), nil)
+		}
+	}
+	return nil
+}
+
+// checkResponse replaces an oversize result with an internal error.
+func (l *Limits) checkResponse(response *jsonrpc.Response) {
+	if l == nil || l.MaxResponseBytes <= 0 || len(response.Result) <= l.MaxResponseBytes {
+		return
+	}
+	size := len(response.Result)
+	response.Result = nil
+	response.Error = jsonrpc.NewInternalError(fmt.Sprintf("response too large: %v bytes exceeds limit of %v", size, l.MaxResponseBytes), nil)
+}
+
+// jsonDepthExceeds returns true when data nests objects or arrays deeper than maxDepth.
+func jsonDepthExceeds(data []byte, maxDepth int) bool {
+	depth := 0
+	inString := false
+	escaped := false
+	for _, c := range data {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '{', '[':
+			if depth++; depth > maxDepth {
+				return true
+			}
+		case '}', ']':
+			depth--
+		}
+	}
+	return false
+}
+
+// bodyLimitMiddleware rejects HTTP request bodies larger than maxBytes with 413 and a JSON-RPC error.
+func bodyLimitMiddleware(maxBytes int64) Middleware {
+	return func(next http.Handler) http.Handler {
+		if maxBytes <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if r.ContentLength > maxBytes {
+				writeTooLarge(w, maxBytes)
+				return
+			}
+			data, err := io.ReadAll(io.LimitReader(r.Body, maxBytes+1))
+			_ = r.Body.Close()
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if int64(len(data)) > maxBytes {
+				writeTooLarge(w, maxBytes)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(data))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeTooLarge(w http.ResponseWriter, maxBytes int64) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusRequestEntityTooLarge)
+	_ = json.NewEncoder(w).Encode(&jsonrpc.Response{
+		Jsonrpc: jsonrpc.Version,
+		Error:   jsonrpc.NewInvalidRequest(fmt.Sprintf("request body exceeds limit of %v bytes", maxBytes), nil),
+	})
+}
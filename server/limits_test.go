@@ -0,0 +1,150 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+)
+
+func TestServer_Limits(t *testing.T) {
+	newHandler := serverproto.WithDefaultHandler(context.Background(), func(server *serverproto.DefaultHandler) error {
+		server.RegisterToolWithSchema("echo", "echoes text", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				text, _ := request.Params.Arguments["text"].(string)
+				return &schema.CallToolResult{Content: []schema.CallToolResultContentElem{schema.TextContent{Type: "text", Text: text}}}, nil
+			})
+		return nil
+	})
+	srv, err := New(WithNewHandler(newHandler), WithLimits(&Limits{MaxParamsBytes: 256, MaxDepth: 4, MaxArguments: 2, MaxResponseBytes: 128}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	ctx := context.Background()
+	aClient := srv.InProcessClient(ctx, nil)
+
+	testCases := []struct {
+		name       string
+		arguments  map[string]interface{}
+		expectCode int
+	}{
+		{name: "within limits", arguments: map[string]interface{}{"text": "hi"}},
+		{name: "params too large", arguments: map[string]interface{}{"text": strings.Repeat("x", 300)}, expectCode: jsonrpc.InvalidRequest},
+		{name: "nested too deep", arguments: map[string]interface{}{"text": map[string]interface{}{"a": map[string]interface{}{"b": []interface{}{1}}}}, expectCode: jsonrpc.InvalidRequest},
+		{name: "too many arguments", arguments: map[string]interface{}{"text": "hi", "b": 1, "c": 2}, expectCode: jsonrpc.InvalidParams},
+		{name: "response too large", arguments: map[string]interface{}{"text": strings.Repeat("y", 150)}, expectCode: jsonrpc.InternalError},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := aClient.CallTool(ctx, &schema.CallToolRequestParams{Name: "echo", Arguments: testCase.arguments})
+			if testCase.expectCode == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			rpcError := &jsonrpc.Error{}
+			if assert.ErrorAs(t, err, &rpcError) {
+				assert.Equal(t, testCase.expectCode, rpcError.Code)
+			}
+		})
+	}
+}
+
+func TestBodyLimitMiddleware(t *testing.T) {
+	handler := bodyLimitMiddleware(16)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(data)
+	}))
+
+	testCases := []struct {
+		name          string
+		body          string
+		chunked       bool
+		expectStatus  int
+		expectPayload string
+	}{
+		{name: "small body", body: `{"a":1}`, expectStatus: http.StatusOK, expectPayload: `{"a":1}`},
+		{name: "declared length too large", body: strings.Repeat("x", 32), expectStatus: http.StatusRequestEntityTooLarge, expectPayload: "exceeds limit"},
+		{name: "streamed body too large", body: strings.Repeat("x", 32), chunked: true, expectStatus: http.StatusRequestEntityTooLarge, expectPayload: "exceeds limit"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(testCase.body))
+			if testCase.chunked {
+				request.ContentLength = -1
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			assert.Equal(t, testCase.expectStatus, recorder.Code)
+			assert.Contains(t, recorder.Body.String(), testCase.expectPayload)
+		})
+	}
+}
+
+func TestLineLimitReader(t *testing.T) {
+	large := strings.Repeat("x", 64)
+	testCases := []struct {
+		name         string
+		input        string
+		expect       string
+		expectMarked bool
+	}{
+		{name: "lines within limit", input: "{\"id\":1}\n{\"id\":2}\n", expect: "{\"id\":1}\n{\"id\":2}\n"},
+		{name: "oversize request replaced", input: `{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"text":"` + large + "\"}}\n{\"id\":2}\n",
+			expect: `{"id":7,"jsonrpc":"2.0","method":"$/oversizeRequest"}` + "\n{\"id\":2}\n", expectMarked: true},
+		{name: "oversize message without id dropped", input: `{"params":{"text":"` + large + "\"},\"id\":7}\n{\"id\":2}\n", expect: "{\"id\":2}\n"},
+		{name: "last line without newline", input: "{\"id\":1}", expect: "{\"id\":1}"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			marks := newOversizeMarks()
+			reader := newLineLimitReader(io.NopCloser(strings.NewReader(testCase.input)), 48, marks)
+			reader.reader = bufio.NewReaderSize(strings.NewReader(testCase.input), 16)
+			data, err := io.ReadAll(reader)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expect, string(data))
+			assert.Equal(t, testCase.expectMarked, marks.take(7))
+		})
+	}
+}
+
+func TestHandler_OversizeRequest(t *testing.T) {
+	newHandler := serverproto.WithDefaultHandler(context.Background(), func(server *serverproto.DefaultHandler) error {
+		return nil
+	})
+	testCases := []struct {
+		name          string
+		marked        bool
+		expectCode    int
+		expectMessage string
+	}{
+		{name: "marked by the reader", marked: true, expectCode: jsonrpc.InvalidRequest, expectMessage: "request exceeds size limit"},
+		{name: "sent by the client", expectCode: jsonrpc.MethodNotFound},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			srv, err := New(WithNewHandler(newHandler))
+			if !assert.NoError(t, err) {
+				return
+			}
+			if testCase.marked {
+				srv.oversize.mark(float64(7))
+			}
+			response := &jsonrpc.Response{}
+			srv.NewHandler(context.Background(), nil).Serve(context.Background(), &jsonrpc.Request{Jsonrpc: jsonrpc.Version, Id: float64(7), Method: methodOversizeRequest}, response)
+			if assert.NotNil(t, response.Error) {
+				assert.Equal(t, testCase.expectCode, response.Error.Code)
+				if testCase.expectMessage != "" {
+					assert.Equal(t, testCase.expectMessage, response.Error.Message)
+				}
+			}
+		})
+	}
+}
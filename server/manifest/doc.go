@@ -0,0 +1,15 @@
+// Package manifest registers tools declared in a YAML or JSON manifest.
+//
+// Each tool declares its name, description, input schema and an executor: either a
+// shell command template run with gosh, or an HTTP request template. Templates use
+// text/template syntax with tool arguments as data, e.g. `ls -la {{.path}}`; values
+// interpolated into a command are shell quoted unless passed through raw; values in an HTTP
+// request are path or query escaped in the URL and JSON encoded in the body. Every command call
+// runs in a fresh local shell unless a shared runner is set with WithRunner.
+// The executor output is mapped into CallToolResult text content, or parsed as JSON
+// into structured content when the output format is json.
+//
+// A Service loads the manifest with afs, registers tools on a DefaultHandler via
+// Service.HandlerOption, and applies manifest changes to every live handler when
+// Reload or Watch detects a modification.
+package manifest
@@ -0,0 +1,161 @@
+package manifest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/viant/gosh/runner"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+// execution holds the raw executor output.
+type execution struct {
+	output string
+	code   int
+	failed bool
+}
+
+// arguments returns call arguments with declared but missing properties set to an empty string.
+func arguments(tool *Tool, request *schema.CallToolRequest) map[string]interface{} {
+	ret := make(map[string]interface{}, len(tool.InputSchema.Properties))
+	for name := range tool.InputSchema.Properties {
+		ret[name] = ""
+	}
+	for name, value := range request.Params.Arguments {
+		ret[name] = value
+	}
+	return ret
+}
+
+func (s *Service) execute(ctx context.Context, tool *Tool, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+	args := arguments(tool, request)
+	var exec *execution
+	var err error
+	switch {
+	case tool.Command != nil:
+		exec, err = s.runCommand(ctx, tool, args)
+	default:
+		exec, err = s.sendHTTP(ctx, tool, args)
+	}
+	if err != nil {
+		return nil, jsonrpc.NewInternalError(fmt.Sprintf("tool %v: %v", tool.Name, err), nil)
+	}
+	result, err := mapOutput(tool, args, exec)
+	if err != nil {
+		return nil, jsonrpc.NewInternalError(fmt.Sprintf("tool %v: failed to map output: %v", tool.Name, err), nil)
+	}
+	return result, nil
+}
+
+func (s *Service) runCommand(ctx context.Context, tool *Tool, args map[string]interface{}) (*execution, error) {
+	command, err := renderCommand(tool.Name, tool.Command.Template, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render command: %w", err)
+	}
+	aRunner, release, err := s.commandRunner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	var options []runner.Option
+	if len(tool.Command.Env) > 0 {
+		options = append(options, runner.WithEnvironment(tool.Command.Env))
+	}
+	if tool.Command.TimeoutMs > 0 {
+		options = append(options, runner.WithTimeout(tool.Command.TimeoutMs))
+	}
+	output, code, err := aRunner.Run(ctx, command, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &execution{output: output, code: code, failed: code != 0}, nil
+}
+
+func (s *Service) sendHTTP(ctx context.Context, tool *Tool, args map[string]interface{}) (*execution, error) {
+	spec := tool.HTTP
+	URL, err := renderURL(tool.Name, spec.URL, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render url: %w", err)
+	}
+	method := spec.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	var body io.Reader
+	if spec.Body != "" {
+		text, err := renderBody(tool.Name, spec.Body, args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render body: %w", err)
+		}
+		body = strings.NewReader(text)
+	}
+	request, err := http.NewRequestWithContext(ctx, strings.ToUpper(method), URL, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range spec.Headers {
+		if value, err = renderHeader(tool.Name, value, args); err != nil {
+			return nil, fmt.Errorf("failed to render header %v: %w", key, err)
+		}
+		request.Header.Set(key, value)
+	}
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(response.Body, s.maxResponseSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.maxResponseSize {
+		return nil, fmt.Errorf("response exceeds %d bytes", s.maxResponseSize)
+	}
+	return &execution{output: string(data), code: response.StatusCode, failed: response.StatusCode >= http.StatusBadRequest}, nil
+}
+
+// mapOutput converts executor output into a tool result.
+func mapOutput(tool *Tool, args map[string]interface{}, exec *execution) (*schema.CallToolResult, error) {
+	output := tool.Output
+	if output == nil {
+		output = &Output{}
+	}
+	result := &schema.CallToolResult{}
+	if exec.failed {
+		isError := true
+		result.IsError = &isError
+	}
+	var data interface{}
+	if output.Format == FormatJSON && !exec.failed {
+		if err := json.Unmarshal([]byte(exec.output), &data); err != nil {
+			return nil, fmt.Errorf("invalid JSON output: %w", err)
+		}
+		if structured, ok := data.(map[string]interface{}); ok {
+			result.StructuredContent = structured
+		} else {
+			result.StructuredContent = map[string]interface{}{"result": data}
+		}
+	}
+	text := exec.output
+	if output.Template != "" && !exec.failed {
+		var err error
+		text, err = render(tool.Name+"-output", output.Template, map[string]interface{}{
+			"Output": exec.output,
+			"Data":   data,
+			"Code":   exec.code,
+			"Args":   args,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	result.Content = []schema.CallToolResultContentElem{
+		schema.TextContent{Type: "text", Text: text},
+	}
+	return result, nil
+}
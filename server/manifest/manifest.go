@@ -0,0 +1,119 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/viant/mcp-protocol/schema"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FormatText maps executor output into text content (default).
+	FormatText = "text"
+	// FormatJSON parses executor output as JSON into structured content.
+	FormatJSON = "json"
+)
+
+// Manifest describes declarative tools.
+type Manifest struct {
+	Tools []*Tool `yaml:"tools" json:"tools"`
+}
+
+// Tool describes a single declarative tool.
+type Tool struct {
+	Name         string                   `yaml:"name" json:"name"`
+	Description  string                   `yaml:"description,omitempty" json:"description,omitempty"`
+	InputSchema  schema.ToolInputSchema   `yaml:"inputSchema,omitempty" json:"inputSchema,omitempty"`
+	OutputSchema *schema.ToolOutputSchema `yaml:"outputSchema,omitempty" json:"outputSchema,omitempty"`
+	Command      *Command                 `yaml:"command,omitempty" json:"command,omitempty"`
+	HTTP         *HTTP                    `yaml:"http,omitempty" json:"http,omitempty"`
+	Output       *Output                  `yaml:"output,omitempty" json:"output,omitempty"`
+}
+
+// Command executes a shell command template.
+type Command struct {
+	Template  string            `yaml:"template" json:"template"`
+	Env       map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+	TimeoutMs int               `yaml:"timeoutMs,omitempty" json:"timeoutMs,omitempty"`
+}
+
+// HTTP executes an HTTP request template; URL, headers and body are templated.
+type HTTP struct {
+	Method  string            `yaml:"method,omitempty" json:"method,omitempty"`
+	URL     string            `yaml:"url" json:"url"`
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+	Body    string            `yaml:"body,omitempty" json:"body,omitempty"`
+}
+
+// Output maps executor output into the tool result.
+type Output struct {
+	// Format is either text (default) or json.
+	Format string `yaml:"format,omitempty" json:"format,omitempty"`
+	// Template renders text content; data exposes .Output, .Data (parsed JSON), .Code and .Args.
+	Template string `yaml:"template,omitempty" json:"template,omitempty"`
+}
+
+// Validate checks tool definitions.
+func (m *Manifest) Validate() error {
+	names := make(map[string]bool, len(m.Tools))
+	for i, tool := range m.Tools {
+		if tool == nil || tool.Name == "" {
+			return fmt.Errorf("tool[%d]: name is required", i)
+		}
+		if names[tool.Name] {
+			return fmt.Errorf("tool %v: duplicate name", tool.Name)
+		}
+		names[tool.Name] = true
+		if (tool.Command == nil) == (tool.HTTP == nil) {
+			return fmt.Errorf("tool %v: exactly one of command or http is required", tool.Name)
+		}
+		if tool.Command != nil && tool.Command.Template == "" {
+			return fmt.Errorf("tool %v: command template is required", tool.Name)
+		}
+		if tool.HTTP != nil && tool.HTTP.URL == "" {
+			return fmt.Errorf("tool %v: http url is required", tool.Name)
+		}
+		if tool.Output != nil {
+			switch tool.Output.Format {
+			case "", FormatText, FormatJSON:
+			default:
+				return fmt.Errorf("tool %v: unsupported output format: %v", tool.Name, tool.Output.Format)
+			}
+		}
+		if tool.InputSchema.Type == "" {
+			tool.InputSchema.Type = "object"
+		}
+	}
+	return nil
+}
+
+// Tool returns a tool by name.
+func (m *Manifest) Tool(name string) *Tool {
+	for _, tool := range m.Tools {
+		if tool.Name == name {
+			return tool
+		}
+	}
+	return nil
+}
+
+// Decode decodes and validates a manifest; location extension selects JSON or YAML (default).
+func Decode(location string, data []byte) (*Manifest, error) {
+	ret := &Manifest{}
+	var err error
+	if strings.EqualFold(path.Ext(location), ".json") {
+		err = json.Unmarshal(data, ret)
+	} else {
+		err = yaml.Unmarshal(data, ret)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode manifest %v: %w", location, err)
+	}
+	if err = ret.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid manifest %v: %w", location, err)
+	}
+	return ret, nil
+}
@@ -0,0 +1,46 @@
+package manifest
+
+import (
+	"net/http"
+
+	"github.com/viant/afs"
+)
+
+// Option customizes Service.
+type Option func(s *Service)
+
+// WithRunner sets a command runner shared by all calls, e.g. a *gosh.Service; commands sent to it are
+// serialized and share its shell state. By default every call runs in a fresh local gosh shell.
+func WithRunner(runner Runner) Option {
+	return func(s *Service) {
+		s.runner = &sharedRunner{Runner: runner}
+	}
+}
+
+// WithMaxResponseSize bounds HTTP executor response bodies (default 10 MiB).
+func WithMaxResponseSize(size int64) Option {
+	return func(s *Service) {
+		s.maxResponseSize = size
+	}
+}
+
+// WithHTTPClient sets the HTTP client used by HTTP executors.
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *Service) {
+		s.httpClient = client
+	}
+}
+
+// WithFS sets the file system used to load the manifest.
+func WithFS(fs afs.Service) Option {
+	return func(s *Service) {
+		s.fs = fs
+	}
+}
+
+// WithErrorHandler sets a callback for reload errors reported by Watch.
+func WithErrorHandler(fn func(err error)) Option {
+	return func(s *Service) {
+		s.onError = fn
+	}
+}
@@ -0,0 +1,202 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+	"weak"
+
+	"github.com/viant/afs"
+	"github.com/viant/gosh"
+	"github.com/viant/gosh/runner"
+	"github.com/viant/gosh/runner/local"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+)
+
+// methodToolsListChanged notifies clients that the tool list has changed.
+const methodToolsListChanged = "notifications/tools/list_changed"
+
+// Runner runs shell commands; *gosh.Service implements it.
+type Runner interface {
+	Run(ctx context.Context, command string, options ...runner.Option) (string, int, error)
+}
+
+// defaultMaxResponseSize bounds HTTP executor responses.
+const defaultMaxResponseSize = 10 << 20
+
+// sharedRunner serializes commands sent to a runner configured with WithRunner.
+type sharedRunner struct {
+	Runner
+	mu sync.Mutex
+}
+
+// Service loads a tool manifest and registers its tools on handlers.
+type Service struct {
+	URL             string
+	fs              afs.Service
+	runner          *sharedRunner
+	httpClient      *http.Client
+	maxResponseSize int64
+	onError         func(err error)
+
+	mu       sync.RWMutex
+	manifest *Manifest
+	loaded   string
+	modTime  time.Time
+	handlers []weak.Pointer[serverproto.DefaultHandler]
+}
+
+// New loads the manifest from URL.
+func New(ctx context.Context, URL string, options ...Option) (*Service, error) {
+	ret := &Service{URL: URL, fs: afs.New(), httpClient: http.DefaultClient, maxResponseSize: defaultMaxResponseSize}
+	for _, option := range options {
+		option(ret)
+	}
+	if _, err := ret.Reload(ctx); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Manifest returns the current manifest.
+func (s *Service) Manifest() *Manifest {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.manifest
+}
+
+// Register registers the current manifest tools on registry; later reloads are not applied.
+func (s *Service) Register(registry *serverproto.Registry) {
+	for _, tool := range s.Manifest().Tools {
+		registry.RegisterTool(s.entry(tool))
+	}
+}
+
+// HandlerOption returns a DefaultHandler option registering manifest tools and applying later reloads.
+func (s *Service) HandlerOption() serverproto.Option {
+	return func(handler *serverproto.DefaultHandler) error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, tool := range s.manifest.Tools {
+			handler.RegisterTool(s.entry(tool))
+		}
+		s.handlers = append(s.handlers, weak.Make(handler))
+		return nil
+	}
+}
+
+// Reload reloads the manifest when it has been modified, or URL changed, since the last load.
+func (s *Service) Reload(ctx context.Context) (bool, error) {
+	object, err := s.fs.Object(ctx, s.URL)
+	if err != nil {
+		return false, fmt.Errorf("failed to locate manifest %v: %w", s.URL, err)
+	}
+	s.mu.RLock()
+	modified := s.manifest == nil || s.loaded != s.URL || !object.ModTime().Equal(s.modTime)
+	s.mu.RUnlock()
+	if !modified {
+		return false, nil
+	}
+	data, err := s.fs.DownloadWithURL(ctx, s.URL)
+	if err != nil {
+		return false, fmt.Errorf("failed to load manifest %v: %w", s.URL, err)
+	}
+	manifest, err := Decode(s.URL, data)
+	if err != nil {
+		return false, err
+	}
+	s.apply(ctx, manifest, s.URL, object.ModTime())
+	return true, nil
+}
+
+// Watch reloads the manifest every interval until ctx is done.
+func (s *Service) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.Reload(ctx); err != nil && s.onError != nil {
+				s.onError(err)
+			}
+		}
+	}
+}
+
+// apply replaces the manifest and updates tools of all live handlers.
+func (s *Service) apply(ctx context.Context, manifest *Manifest, URL string, modTime time.Time) {
+	s.mu.Lock()
+	previous := s.manifest
+	s.manifest = manifest
+	s.loaded = URL
+	s.modTime = modTime
+	var live []weak.Pointer[serverproto.DefaultHandler]
+	var handlers []*serverproto.DefaultHandler
+	for _, pointer := range s.handlers {
+		if handler := pointer.Value(); handler != nil {
+			live = append(live, pointer)
+			handlers = append(handlers, handler)
+		}
+	}
+	s.handlers = live
+	s.mu.Unlock()
+	if previous == nil {
+		return
+	}
+	for _, handler := range handlers {
+		for _, tool := range previous.Tools {
+			if manifest.Tool(tool.Name) == nil {
+				handler.ToolRegistry.Delete(tool.Name)
+			}
+		}
+		for _, tool := range manifest.Tools {
+			handler.RegisterTool(s.entry(tool))
+		}
+		if handler.Notifier != nil {
+			if notification, err := jsonrpc.NewNotification(methodToolsListChanged, map[string]interface{}{}); err == nil {
+				_ = handler.Notifier.Notify(ctx, notification)
+			}
+		}
+	}
+}
+
+// entry creates a registry entry resolving the tool definition at call time.
+func (s *Service) entry(tool *Tool) *serverproto.ToolEntry {
+	description := tool.Description
+	name := tool.Name
+	return &serverproto.ToolEntry{
+		Metadata: schema.Tool{
+			Name:         name,
+			Description:  &description,
+			InputSchema:  tool.InputSchema,
+			OutputSchema: tool.OutputSchema,
+		},
+		Handler: func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+			current := s.Manifest().Tool(name)
+			if current == nil {
+				return nil, jsonrpc.NewInvalidParamsError(fmt.Sprintf("tool %v no longer exists", name), nil)
+			}
+			return s.execute(ctx, current, request)
+		},
+	}
+}
+
+// commandRunner returns the configured runner, held until release is called, or a fresh local gosh shell
+// closed on release, so that calls neither share shell state nor wait for each other.
+func (s *Service) commandRunner(ctx context.Context) (Runner, func(), error) {
+	if s.runner != nil {
+		s.runner.mu.Lock()
+		return s.runner.Runner, s.runner.mu.Unlock, nil
+	}
+	service, err := gosh.New(ctx, local.New())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to start shell: %w", err)
+	}
+	return service, func() { _ = service.Close() }, nil
+}
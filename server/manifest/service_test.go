@@ -0,0 +1,259 @@
+package manifest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/gosh/runner"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+)
+
+type recordingRunner struct {
+	commands []string
+}
+
+func (r *recordingRunner) Run(ctx context.Context, command string, options ...runner.Option) (string, int, error) {
+	r.commands = append(r.commands, command)
+	return "ran: " + command, 0, nil
+}
+
+func TestService(t *testing.T) {
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("city") == "large" {
+			_, _ = w.Write(make([]byte, 2048))
+			return
+		}
+		if r.URL.Query().Get("city") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("city is required"))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"city":%q,"temp":21}`, r.URL.Query().Get("city"))
+	}))
+	defer httpServer.Close()
+
+	manifestYAML := `tools:
+  - name: list
+    description: lists files
+    inputSchema:
+      type: object
+      properties:
+        path: {type: string}
+        flags: {type: string}
+    command:
+      template: ls {{raw .flags}} {{.path}}
+  - name: weather
+    inputSchema:
+      properties:
+        city: {type: string}
+    http:
+      url: ` + httpServer.URL + `/weather?city={{urlquery .city}}
+    output:
+      format: json
+      template: "{{.Data.city}}: {{.Data.temp}}C"
+`
+	dir := t.TempDir()
+	location := filepath.Join(dir, "tools.yaml")
+	if !assert.NoError(t, os.WriteFile(location, []byte(manifestYAML), 0o644)) {
+		return
+	}
+	ctx := context.Background()
+	aRunner := &recordingRunner{}
+	service, err := New(ctx, location, WithRunner(aRunner), WithMaxResponseSize(1024))
+	if !assert.NoError(t, err) {
+		return
+	}
+	handler := serverproto.NewDefaultHandler(nil, nil, nil)
+	if !assert.NoError(t, service.HandlerOption()(handler)) {
+		return
+	}
+
+	testCases := []struct {
+		name             string
+		tool             string
+		args             map[string]interface{}
+		expectText       string
+		expectStructured map[string]interface{}
+		expectIsError    bool
+		expectErr        bool
+	}{
+		{name: "command template", tool: "list", args: map[string]interface{}{"path": "it's here", "flags": "-la"}, expectText: `ran: ls -la 'it'\''s here'`},
+		{name: "command value quoted", tool: "list", args: map[string]interface{}{"path": "x; rm -rf ~"}, expectText: `ran: ls  'x; rm -rf ~'`},
+		{name: "http json output", tool: "weather", args: map[string]interface{}{"city": "Paris"}, expectText: "Paris: 21C", expectStructured: map[string]interface{}{"city": "Paris", "temp": float64(21)}},
+		{name: "http error status", tool: "weather", expectText: "city is required", expectIsError: true},
+		{name: "http response too large", tool: "weather", args: map[string]interface{}{"city": "large"}, expectErr: true},
+		{name: "unknown tool", tool: "missing", expectErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, rErr := handler.CallTool(ctx, &jsonrpc.TypedRequest[*schema.CallToolRequest]{Request: &schema.CallToolRequest{
+				Params: schema.CallToolRequestParams{Name: tc.tool, Arguments: tc.args},
+			}})
+			if tc.expectErr {
+				assert.NotNil(t, rErr)
+				return
+			}
+			if !assert.Nil(t, rErr) {
+				return
+			}
+			assert.EqualValues(t, tc.expectText, result.Content[0].(schema.TextContent).Text)
+			assert.EqualValues(t, tc.expectIsError, result.IsError != nil && *result.IsError)
+			if tc.expectStructured != nil {
+				assert.EqualValues(t, tc.expectStructured, result.StructuredContent)
+			}
+		})
+	}
+
+	updated := `{"tools":[{"name":"echo","command":{"template":"echo {{quote .text}}"}}]}`
+	location = filepath.Join(dir, "tools.json")
+	assert.NoError(t, os.WriteFile(location, []byte(updated), 0o644))
+	service.URL = location
+	reloaded, err := service.Reload(ctx)
+	assert.NoError(t, err)
+	assert.True(t, reloaded)
+	var names []string
+	for _, tool := range handler.ListRegisteredTools() {
+		names = append(names, tool.Name)
+	}
+	assert.EqualValues(t, []string{"echo"}, names)
+
+	later := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(location, later, later))
+	assert.NoError(t, os.WriteFile(location, []byte(`{"tools":[{"name":"echo"}]}`), 0o644))
+	assert.NoError(t, os.Chtimes(location, later.Add(time.Minute), later.Add(time.Minute)))
+	_, err = service.Reload(ctx)
+	assert.Error(t, err, "invalid manifest is rejected")
+	assert.NotNil(t, service.Manifest().Tool("echo").Command, "previous manifest is kept")
+}
+
+func TestService_HTTPTemplates(t *testing.T) {
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"path": r.URL.EscapedPath(), "query": r.URL.Query(), "body": body, "trace": r.Header.Get("X-Trace")})
+	}))
+	defer httpServer.Close()
+
+	manifestYAML := `tools:
+  - name: order
+    inputSchema:
+      properties:
+        id: {type: string}
+        note: {type: string}
+        trace: {type: string}
+    http:
+      method: POST
+      url: ` + httpServer.URL + `/orders/{{.id}}?note={{.note}}
+      headers:
+        X-Trace: "{{.trace}}"
+      body: '{"id":{{.id}},"note":{{.note}}}'
+    output:
+      format: json
+`
+	location := filepath.Join(t.TempDir(), "tools.yaml")
+	if !assert.NoError(t, os.WriteFile(location, []byte(manifestYAML), 0o644)) {
+		return
+	}
+	ctx := context.Background()
+	service, err := New(ctx, location)
+	if !assert.NoError(t, err) {
+		return
+	}
+	handler := serverproto.NewDefaultHandler(nil, nil, nil)
+	if !assert.NoError(t, service.HandlerOption()(handler)) {
+		return
+	}
+
+	testCases := []struct {
+		name      string
+		args      map[string]interface{}
+		expect    map[string]interface{}
+		expectErr bool
+	}{
+		{
+			name: "plain values",
+			args: map[string]interface{}{"id": "42", "note": "rush", "trace": "t1"},
+			expect: map[string]interface{}{"path": "/orders/42", "query": map[string]interface{}{"note": []interface{}{"rush"}},
+				"body": map[string]interface{}{"id": "42", "note": "rush"}, "trace": "t1"},
+		},
+		{
+			name: "values cannot change path, query or body fields",
+			args: map[string]interface{}{"id": "../admin?all=1", "note": `x&admin=1","admin":"true`},
+			expect: map[string]interface{}{"path": "/orders/..%2Fadmin%3Fall=1", "query": map[string]interface{}{"note": []interface{}{`x&admin=1","admin":"true`}},
+				"body": map[string]interface{}{"id": "../admin?all=1", "note": `x&admin=1","admin":"true`}, "trace": ""},
+		},
+		{
+			name:      "header line break rejected",
+			args:      map[string]interface{}{"id": "1", "trace": "t1\r\nX-Admin: 1"},
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, rErr := handler.CallTool(ctx, &jsonrpc.TypedRequest[*schema.CallToolRequest]{Request: &schema.CallToolRequest{
+				Params: schema.CallToolRequestParams{Name: "order", Arguments: tc.args},
+			}})
+			if tc.expectErr {
+				assert.NotNil(t, rErr)
+				return
+			}
+			if !assert.Nil(t, rErr) {
+				return
+			}
+			assert.EqualValues(t, tc.expect, result.StructuredContent)
+		})
+	}
+}
+
+func TestService_CommandShell(t *testing.T) {
+	manifestYAML := `tools:
+  - name: state
+    inputSchema:
+      properties:
+        value: {type: string}
+    command:
+      template: echo "[$MANIFEST_STATE]"; export MANIFEST_STATE={{.value}}
+`
+	location := filepath.Join(t.TempDir(), "tools.yaml")
+	if !assert.NoError(t, os.WriteFile(location, []byte(manifestYAML), 0o644)) {
+		return
+	}
+	ctx := context.Background()
+	service, err := New(ctx, location)
+	if !assert.NoError(t, err) {
+		return
+	}
+	handler := serverproto.NewDefaultHandler(nil, nil, nil)
+	if !assert.NoError(t, service.HandlerOption()(handler)) {
+		return
+	}
+	testCases := []struct {
+		name  string
+		value string
+	}{
+		{name: "first call", value: "first"},
+		{name: "second call does not see first call state", value: "second"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, rErr := handler.CallTool(ctx, &jsonrpc.TypedRequest[*schema.CallToolRequest]{Request: &schema.CallToolRequest{
+				Params: schema.CallToolRequestParams{Name: "state", Arguments: map[string]interface{}{"value": tc.value}},
+			}})
+			if !assert.Nil(t, rErr) {
+				return
+			}
+			assert.EqualValues(t, "[]", strings.TrimSpace(result.Content[0].(schema.TextContent).Text))
+		})
+	}
+}
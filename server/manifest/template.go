@@ -0,0 +1,173 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+var funcs = template.FuncMap{
+	"quote":       shellQuote,
+	"raw":         raw,
+	"pathEscape":  func(v interface{}) string { return url.PathEscape(raw(v)) },
+	"queryEscape": func(v interface{}) string { return url.QueryEscape(raw(v)) },
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join": func(sep string, v interface{}) string {
+		items, _ := v.([]interface{})
+		texts := make([]string, 0, len(items))
+		for _, item := range items {
+			if text, ok := item.(string); ok {
+				texts = append(texts, text)
+				continue
+			}
+			data, _ := json.Marshal(item)
+			texts = append(texts, string(data))
+		}
+		return strings.Join(texts, sep)
+	},
+}
+
+// escaping names the function appended to every interpolated value, and the functions whose output is
+// already escaped for the same context.
+type escaping struct {
+	name    string
+	escaped map[string]bool
+}
+
+var (
+	shellEscaping = &escaping{name: "quote", escaped: map[string]bool{"quote": true, "raw": true}}
+	pathEscaping  = &escaping{name: "pathEscape", escaped: map[string]bool{"pathEscape": true, "queryEscape": true, "urlquery": true, "raw": true}}
+	queryEscaping = &escaping{name: "queryEscape", escaped: map[string]bool{"pathEscape": true, "queryEscape": true, "urlquery": true, "raw": true}}
+	jsonEscaping  = &escaping{name: "json", escaped: map[string]bool{"json": true, "raw": true}}
+)
+
+// render executes text as a template with data.
+func render(name, text string, data interface{}) (string, error) {
+	return execute(name, text, data, nil)
+}
+
+// renderCommand executes a command template with data; every interpolated value is shell quoted
+// unless its pipeline ends with quote or raw.
+func renderCommand(name, text string, data interface{}) (string, error) {
+	return execute(name, text, data, shellEscaping)
+}
+
+// renderURL executes a URL template with data; values are path escaped before the first "?" and query
+// escaped after it, unless their pipeline ends with an URL escaping function or raw.
+func renderURL(name, text string, data interface{}) (string, error) {
+	path, query, hasQuery := strings.Cut(text, "?")
+	ret, err := execute(name, path, data, pathEscaping)
+	if err != nil || !hasQuery {
+		return ret, err
+	}
+	if query, err = execute(name, query, data, queryEscaping); err != nil {
+		return "", err
+	}
+	return ret + "?" + query, nil
+}
+
+// renderBody executes a request body template with data; every value is JSON encoded unless its pipeline
+// ends with json or raw, e.g. {"city":{{.city}}}.
+func renderBody(name, text string, data interface{}) (string, error) {
+	return execute(name, text, data, jsonEscaping)
+}
+
+// renderHeader executes a header template with data, rejecting values that would split the header.
+func renderHeader(name, text string, data interface{}) (string, error) {
+	ret, err := execute(name, text, data, nil)
+	if err != nil {
+		return "", err
+	}
+	if strings.ContainsAny(ret, "\r\n") {
+		return "", fmt.Errorf("header value contains a line break")
+	}
+	return ret, nil
+}
+
+func execute(name, text string, data interface{}, escaping *escaping) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tmpl, err := template.New(name).Option("missingkey=error").Funcs(funcs).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	if escaping != nil {
+		for _, aTemplate := range tmpl.Templates() {
+			if aTemplate.Tree != nil {
+				escapeActions(aTemplate.Tree, aTemplate.Tree.Root, escaping)
+			}
+		}
+	}
+	buf := &bytes.Buffer{}
+	if err = tmpl.Execute(buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// escapeActions appends the escaping function to every action printing a value that is not already escaped.
+func escapeActions(tree *parse.Tree, node parse.Node, escaping *escaping) {
+	switch actual := node.(type) {
+	case *parse.ListNode:
+		if actual == nil {
+			return
+		}
+		for _, child := range actual.Nodes {
+			escapeActions(tree, child, escaping)
+		}
+	case *parse.ActionNode:
+		pipe := actual.Pipe
+		if len(pipe.Decl) > 0 || len(pipe.Cmds) == 0 {
+			return
+		}
+		last := pipe.Cmds[len(pipe.Cmds)-1]
+		if identifier, ok := last.Args[0].(*parse.IdentifierNode); ok && escaping.escaped[identifier.Ident] {
+			return
+		}
+		escape := parse.NewIdentifier(escaping.name).SetTree(tree).SetPos(pipe.Pos)
+		pipe.Cmds = append(pipe.Cmds, &parse.CommandNode{NodeType: parse.NodeCommand, Pos: pipe.Pos, Args: []parse.Node{escape}})
+	case *parse.IfNode:
+		escapeActions(tree, actual.List, escaping)
+		escapeActions(tree, actual.ElseList, escaping)
+	case *parse.RangeNode:
+		escapeActions(tree, actual.List, escaping)
+		escapeActions(tree, actual.ElseList, escaping)
+	case *parse.WithNode:
+		escapeActions(tree, actual.List, escaping)
+		escapeActions(tree, actual.ElseList, escaping)
+	}
+}
+
+// raw marks a value to be interpolated into a command without quoting.
+func raw(v interface{}) string {
+	switch actual := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return actual
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+// shellQuote quotes a value as a single shell word.
+func shellQuote(v interface{}) string {
+	var text string
+	switch actual := v.(type) {
+	case nil:
+	case string:
+		text = actual
+	default:
+		data, _ := json.Marshal(actual)
+		text = string(data)
+	}
+	return "'" + strings.ReplaceAll(text, "'", `'\''`) + "'"
+}
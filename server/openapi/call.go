@@ -0,0 +1,133 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+// call executes the tool operation with args and maps the response into a tool result.
+func (s *Service) call(ctx context.Context, tool *Tool, args map[string]interface{}) (*schema.CallToolResult, *jsonrpc.Error) {
+	request, err := s.newRequest(ctx, tool, args)
+	if err != nil {
+		return nil, jsonrpc.NewInvalidParamsError(err.Error(), nil)
+	}
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return nil, jsonrpc.NewInternalError(fmt.Sprintf("%v %v: %v", request.Method, request.URL.Path, err), nil)
+	}
+	defer response.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(response.Body, s.maxResponseSize+1))
+	if err != nil {
+		return nil, jsonrpc.NewInternalError(err.Error(), nil)
+	}
+	if int64(len(data)) > s.maxResponseSize {
+		return nil, jsonrpc.NewInternalError(fmt.Sprintf("%v %v: response exceeds %d bytes", request.Method, request.URL.Path, s.maxResponseSize), nil)
+	}
+	result := &schema.CallToolResult{
+		Content: []schema.CallToolResultContentElem{schema.TextContent{Type: "text", Text: string(data)}},
+	}
+	if response.StatusCode >= http.StatusBadRequest {
+		isError := true
+		result.IsError = &isError
+	}
+	if isJSON(response.Header.Get("Content-Type")) && len(data) > 0 {
+		var value interface{}
+		if err = json.Unmarshal(data, &value); err == nil {
+			if structured, ok := value.(map[string]interface{}); ok {
+				result.StructuredContent = structured
+			} else {
+				result.StructuredContent = map[string]interface{}{"result": value}
+			}
+		}
+	}
+	return result, nil
+}
+
+func (s *Service) newRequest(ctx context.Context, tool *Tool, args map[string]interface{}) (*http.Request, error) {
+	if tool.BaseURL == "" {
+		return nil, fmt.Errorf("%v: server URL is not defined", tool.Metadata.Name)
+	}
+	aPath := tool.Path
+	query := url.Values{}
+	header := http.Header{}
+	var cookies []*http.Cookie
+	for _, param := range tool.Parameters {
+		value, ok := args[param.Name]
+		if !ok || value == nil {
+			if param.Required || param.In == "path" {
+				return nil, fmt.Errorf("%v: missing required parameter %v", tool.Metadata.Name, param.Name)
+			}
+			continue
+		}
+		switch param.In {
+		case "path":
+			aPath = strings.ReplaceAll(aPath, "{"+param.Name+"}", url.PathEscape(formatValue(value)))
+		case "query":
+			if items, ok := value.([]interface{}); ok {
+				for _, item := range items {
+					query.Add(param.Name, formatValue(item))
+				}
+				continue
+			}
+			query.Set(param.Name, formatValue(value))
+		case "header":
+			header.Set(param.Name, formatValue(value))
+		case "cookie":
+			cookies = append(cookies, &http.Cookie{Name: param.Name, Value: formatValue(value)})
+		}
+	}
+	URL := strings.TrimRight(tool.BaseURL, "/") + aPath
+	if len(query) > 0 {
+		URL += "?" + query.Encode()
+	}
+	var body io.Reader
+	if value, ok := args[tool.BodyArgument]; ok && tool.BodyArgument != "" {
+		if text, ok := value.(string); ok && !isJSON(tool.ContentType) {
+			body = strings.NewReader(text)
+		} else {
+			data, err := json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			body = bytes.NewReader(data)
+		}
+		header.Set("Content-Type", tool.ContentType)
+	}
+	request, err := http.NewRequestWithContext(ctx, tool.Method, URL, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		request.Header[key] = values
+	}
+	for _, cookie := range cookies {
+		request.AddCookie(cookie)
+	}
+	return request, nil
+}
+
+// formatValue formats a JSON argument as a parameter value.
+func formatValue(value interface{}) string {
+	switch actual := value.(type) {
+	case string:
+		return actual
+	case float64:
+		return strconv.FormatFloat(actual, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(actual)
+	case json.Number:
+		return actual.String()
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
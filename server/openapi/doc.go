@@ -0,0 +1,10 @@
+// Package openapi registers one MCP tool per operation of an OpenAPI 3 document.
+//
+// The document is loaded with afs from a file or URL. Each operation input schema
+// is derived from its path, query, header and cookie parameters, with the JSON
+// request body exposed as the "body" argument ("requestBody" when a parameter is
+// named body). Calls are executed with a
+// configurable http.Client, so an authenticating round tripper such as
+// client/auth/transport.RoundTripper can be used, and JSON responses are mapped
+// into structured content. Operations can be filtered by tag or operationId.
+package openapi
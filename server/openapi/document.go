@@ -0,0 +1,182 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxRefDepth guards against cyclic schema references.
+const maxRefDepth = 16
+
+// Document represents the subset of an OpenAPI 3 document used to build tools.
+type Document struct {
+	OpenAPI    string               `yaml:"openapi" json:"openapi"`
+	Servers    []*Server            `yaml:"servers,omitempty" json:"servers,omitempty"`
+	Paths      map[string]*PathItem `yaml:"paths" json:"paths"`
+	Components *Components          `yaml:"components,omitempty" json:"components,omitempty"`
+}
+
+// Server represents an API server.
+type Server struct {
+	URL string `yaml:"url" json:"url"`
+}
+
+// Components holds reusable definitions referenced with $ref.
+type Components struct {
+	Schemas       map[string]map[string]interface{} `yaml:"schemas,omitempty" json:"schemas,omitempty"`
+	Parameters    map[string]*Parameter             `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	RequestBodies map[string]*RequestBody           `yaml:"requestBodies,omitempty" json:"requestBodies,omitempty"`
+}
+
+// PathItem holds operations of a single path.
+type PathItem struct {
+	Parameters []*Parameter `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	Get        *Operation   `yaml:"get,omitempty" json:"get,omitempty"`
+	Put        *Operation   `yaml:"put,omitempty" json:"put,omitempty"`
+	Post       *Operation   `yaml:"post,omitempty" json:"post,omitempty"`
+	Delete     *Operation   `yaml:"delete,omitempty" json:"delete,omitempty"`
+	Patch      *Operation   `yaml:"patch,omitempty" json:"patch,omitempty"`
+	Head       *Operation   `yaml:"head,omitempty" json:"head,omitempty"`
+	Options    *Operation   `yaml:"options,omitempty" json:"options,omitempty"`
+}
+
+// Operations returns path operations keyed by HTTP method.
+func (p *PathItem) Operations() map[string]*Operation {
+	ret := map[string]*Operation{}
+	for method, operation := range map[string]*Operation{
+		"GET": p.Get, "PUT": p.Put, "POST": p.Post, "DELETE": p.Delete, "PATCH": p.Patch, "HEAD": p.Head, "OPTIONS": p.Options,
+	} {
+		if operation != nil {
+			ret[method] = operation
+		}
+	}
+	return ret
+}
+
+// Operation represents an API operation.
+type Operation struct {
+	OperationID string       `yaml:"operationId,omitempty" json:"operationId,omitempty"`
+	Summary     string       `yaml:"summary,omitempty" json:"summary,omitempty"`
+	Description string       `yaml:"description,omitempty" json:"description,omitempty"`
+	Tags        []string     `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Parameters  []*Parameter `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	RequestBody *RequestBody `yaml:"requestBody,omitempty" json:"requestBody,omitempty"`
+	Servers     []*Server    `yaml:"servers,omitempty" json:"servers,omitempty"`
+}
+
+// Parameter represents an operation parameter.
+type Parameter struct {
+	Ref         string                 `yaml:"$ref,omitempty" json:"$ref,omitempty"`
+	Name        string                 `yaml:"name,omitempty" json:"name,omitempty"`
+	In          string                 `yaml:"in,omitempty" json:"in,omitempty"`
+	Description string                 `yaml:"description,omitempty" json:"description,omitempty"`
+	Required    bool                   `yaml:"required,omitempty" json:"required,omitempty"`
+	Schema      map[string]interface{} `yaml:"schema,omitempty" json:"schema,omitempty"`
+}
+
+// RequestBody represents an operation request body.
+type RequestBody struct {
+	Ref         string                `yaml:"$ref,omitempty" json:"$ref,omitempty"`
+	Description string                `yaml:"description,omitempty" json:"description,omitempty"`
+	Required    bool                  `yaml:"required,omitempty" json:"required,omitempty"`
+	Content     map[string]*MediaType `yaml:"content,omitempty" json:"content,omitempty"`
+}
+
+// MediaType represents a request body media type.
+type MediaType struct {
+	Schema map[string]interface{} `yaml:"schema,omitempty" json:"schema,omitempty"`
+}
+
+// Decode decodes an OpenAPI 3 document; location extension selects JSON, otherwise YAML is assumed.
+func Decode(location string, data []byte) (*Document, error) {
+	ret := &Document{}
+	var err error
+	if strings.EqualFold(path.Ext(location), ".json") {
+		err = json.Unmarshal(data, ret)
+	} else {
+		err = yaml.Unmarshal(data, ret)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode OpenAPI document %v: %w", location, err)
+	}
+	if !strings.HasPrefix(ret.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q in %v", ret.OpenAPI, location)
+	}
+	return ret, nil
+}
+
+// parameter resolves a parameter reference.
+func (d *Document) parameter(param *Parameter) (*Parameter, error) {
+	if param.Ref == "" {
+		return param, nil
+	}
+	name, ok := strings.CutPrefix(param.Ref, "#/components/parameters/")
+	if ok && d.Components != nil {
+		if ret, ok := d.Components.Parameters[name]; ok && ret.Ref == "" {
+			return ret, nil
+		}
+	}
+	return nil, fmt.Errorf("unresolved parameter reference: %v", param.Ref)
+}
+
+// requestBody resolves a request body reference.
+func (d *Document) requestBody(body *RequestBody) (*RequestBody, error) {
+	if body.Ref == "" {
+		return body, nil
+	}
+	name, ok := strings.CutPrefix(body.Ref, "#/components/requestBodies/")
+	if ok && d.Components != nil {
+		if ret, ok := d.Components.RequestBodies[name]; ok && ret.Ref == "" {
+			return ret, nil
+		}
+	}
+	return nil, fmt.Errorf("unresolved request body reference: %v", body.Ref)
+}
+
+// schema returns a copy of aSchema with component schema references inlined.
+func (d *Document) schema(aSchema map[string]interface{}, depth int) (map[string]interface{}, error) {
+	if aSchema == nil {
+		return nil, nil
+	}
+	if depth > maxRefDepth {
+		return map[string]interface{}{}, nil
+	}
+	if ref, ok := aSchema["$ref"].(string); ok {
+		name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+		if !ok || d.Components == nil || d.Components.Schemas[name] == nil {
+			return nil, fmt.Errorf("unresolved schema reference: %v", ref)
+		}
+		return d.schema(d.Components.Schemas[name], depth+1)
+	}
+	ret := make(map[string]interface{}, len(aSchema))
+	for key, value := range aSchema {
+		resolved, err := d.resolveValue(value, depth)
+		if err != nil {
+			return nil, err
+		}
+		ret[key] = resolved
+	}
+	return ret, nil
+}
+
+func (d *Document) resolveValue(value interface{}, depth int) (interface{}, error) {
+	switch actual := value.(type) {
+	case map[string]interface{}:
+		return d.schema(actual, depth)
+	case []interface{}:
+		ret := make([]interface{}, len(actual))
+		for i, item := range actual {
+			resolved, err := d.resolveValue(item, depth)
+			if err != nil {
+				return nil, err
+			}
+			ret[i] = resolved
+		}
+		return ret, nil
+	}
+	return value, nil
+}
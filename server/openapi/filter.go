@@ -0,0 +1,32 @@
+package openapi
+
+// filter selects operations by tag and operationId; exclusions take precedence.
+type filter struct {
+	includeTags       map[string]bool
+	excludeTags       map[string]bool
+	includeOperations map[string]bool
+	excludeOperations map[string]bool
+}
+
+func (f *filter) matches(operation *Operation) bool {
+	if f.excludeOperations[operation.OperationID] {
+		return false
+	}
+	for _, tag := range operation.Tags {
+		if f.excludeTags[tag] {
+			return false
+		}
+	}
+	if len(f.includeTags) == 0 && len(f.includeOperations) == 0 {
+		return true
+	}
+	if f.includeOperations[operation.OperationID] {
+		return true
+	}
+	for _, tag := range operation.Tags {
+		if f.includeTags[tag] {
+			return true
+		}
+	}
+	return false
+}
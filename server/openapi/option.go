@@ -0,0 +1,74 @@
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/viant/afs"
+)
+
+// Option customizes Service.
+type Option func(s *Service)
+
+// WithHTTPClient sets the HTTP client used to call operations, e.g. one with an authenticating transport.
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *Service) {
+		s.httpClient = client
+	}
+}
+
+// WithMaxResponseSize bounds operation response bodies (default 10 MiB).
+func WithMaxResponseSize(size int64) Option {
+	return func(s *Service) {
+		s.maxResponseSize = size
+	}
+}
+
+// WithBaseURL overrides the server URL declared by the document.
+func WithBaseURL(URL string) Option {
+	return func(s *Service) {
+		s.baseURL = URL
+	}
+}
+
+// WithFS sets the file system used to load the document.
+func WithFS(fs afs.Service) Option {
+	return func(s *Service) {
+		s.fs = fs
+	}
+}
+
+// WithIncludeTags registers only operations having at least one of tags.
+func WithIncludeTags(tags ...string) Option {
+	return func(s *Service) {
+		s.filter.includeTags = asSet(tags)
+	}
+}
+
+// WithExcludeTags skips operations having any of tags.
+func WithExcludeTags(tags ...string) Option {
+	return func(s *Service) {
+		s.filter.excludeTags = asSet(tags)
+	}
+}
+
+// WithIncludeOperations registers only operations with the given operationIds.
+func WithIncludeOperations(operationIDs ...string) Option {
+	return func(s *Service) {
+		s.filter.includeOperations = asSet(operationIDs)
+	}
+}
+
+// WithExcludeOperations skips operations with the given operationIds.
+func WithExcludeOperations(operationIDs ...string) Option {
+	return func(s *Service) {
+		s.filter.excludeOperations = asSet(operationIDs)
+	}
+}
+
+func asSet(values []string) map[string]bool {
+	ret := make(map[string]bool, len(values))
+	for _, value := range values {
+		ret[value] = true
+	}
+	return ret
+}
@@ -0,0 +1,269 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/viant/afs"
+	"github.com/viant/afs/url"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+)
+
+const (
+	// bodyArgument is the tool argument holding the request body.
+	bodyArgument = "body"
+	// fallbackBodyArgument holds the request body when an operation declares a parameter named body.
+	fallbackBodyArgument = "requestBody"
+	// defaultMaxResponseSize bounds operation responses.
+	defaultMaxResponseSize = 10 << 20
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// Service builds MCP tools from an OpenAPI document.
+type Service struct {
+	URL             string
+	fs              afs.Service
+	httpClient      *http.Client
+	maxResponseSize int64
+	baseURL         string
+	filter          filter
+	document        *Document
+	tools           []*Tool
+}
+
+// Tool represents an operation exposed as an MCP tool.
+type Tool struct {
+	Metadata    schema.Tool
+	Method      string
+	Path        string
+	BaseURL     string
+	Operation   *Operation
+	Parameters  []*Parameter
+	ContentType string
+	// BodyArgument names the argument holding the request body: body, or requestBody when a parameter is named body.
+	BodyArgument string
+}
+
+// New loads the OpenAPI document from URL and builds tools for the selected operations.
+func New(ctx context.Context, URL string, options ...Option) (*Service, error) {
+	ret := &Service{URL: URL, fs: afs.New(), httpClient: http.DefaultClient, maxResponseSize: defaultMaxResponseSize}
+	for _, option := range options {
+		option(ret)
+	}
+	data, err := ret.fs.DownloadWithURL(ctx, URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI document %v: %w", URL, err)
+	}
+	if ret.document, err = Decode(URL, data); err != nil {
+		return nil, err
+	}
+	if ret.tools, err = ret.buildTools(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Document returns the loaded OpenAPI document.
+func (s *Service) Document() *Document {
+	return s.document
+}
+
+// Tools returns tools built from the document.
+func (s *Service) Tools() []*Tool {
+	return s.tools
+}
+
+// Register registers all tools on registry.
+func (s *Service) Register(registry *serverproto.Registry) {
+	for _, tool := range s.tools {
+		tool := tool
+		registry.RegisterTool(&serverproto.ToolEntry{
+			Metadata: tool.Metadata,
+			Handler: func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				return s.call(ctx, tool, request.Params.Arguments)
+			},
+		})
+	}
+}
+
+// HandlerOption returns a DefaultHandler option registering all tools.
+func (s *Service) HandlerOption() serverproto.Option {
+	return func(handler *serverproto.DefaultHandler) error {
+		s.Register(handler.Registry)
+		return nil
+	}
+}
+
+func (s *Service) buildTools() ([]*Tool, error) {
+	paths := make([]string, 0, len(s.document.Paths))
+	for aPath := range s.document.Paths {
+		paths = append(paths, aPath)
+	}
+	sort.Strings(paths)
+	var ret []*Tool
+	names := map[string]string{}
+	for _, aPath := range paths {
+		item := s.document.Paths[aPath]
+		operations := item.Operations()
+		methods := make([]string, 0, len(operations))
+		for method := range operations {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			operation := operations[method]
+			if !s.filter.matches(operation) {
+				continue
+			}
+			tool, err := s.buildTool(method, aPath, item, operation)
+			if err != nil {
+				return nil, fmt.Errorf("%v %v: %w", method, aPath, err)
+			}
+			if previous, ok := names[tool.Metadata.Name]; ok {
+				return nil, fmt.Errorf("%v %v: tool name %v already used by %v", method, aPath, tool.Metadata.Name, previous)
+			}
+			names[tool.Metadata.Name] = method + " " + aPath
+			ret = append(ret, tool)
+		}
+	}
+	return ret, nil
+}
+
+func (s *Service) buildTool(method, aPath string, item *PathItem, operation *Operation) (*Tool, error) {
+	name := operation.OperationID
+	if name == "" {
+		name = strings.ToLower(method) + "_" + aPath
+	}
+	name = strings.Trim(invalidNameChars.ReplaceAllString(name, "_"), "_")
+	description := operation.Summary
+	if operation.Description != "" {
+		if description != "" {
+			description += "\n\n"
+		}
+		description += operation.Description
+	}
+	ret := &Tool{Method: method, Path: aPath, Operation: operation, BaseURL: s.serverURL(operation)}
+	inputSchema := schema.ToolInputSchema{Type: "object", Properties: map[string]map[string]interface{}{}}
+
+	// operation parameters override path level parameters with the same name and location
+	byKey := map[string]*Parameter{}
+	var keys []string
+	for _, candidate := range append(append([]*Parameter{}, item.Parameters...), operation.Parameters...) {
+		param, err := s.document.parameter(candidate)
+		if err != nil {
+			return nil, err
+		}
+		key := param.In + ":" + param.Name
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = param
+	}
+	for _, key := range keys {
+		param := byKey[key]
+		if _, ok := inputSchema.Properties[param.Name]; ok {
+			return nil, fmt.Errorf("%v %v: parameter %v is declared in more than one location", method, aPath, param.Name)
+		}
+		property, err := s.document.schema(param.Schema, 0)
+		if err != nil {
+			return nil, err
+		}
+		if property == nil {
+			property = map[string]interface{}{"type": "string"}
+		}
+		if param.Description != "" {
+			property["description"] = param.Description
+		}
+		inputSchema.Properties[param.Name] = property
+		if param.Required || param.In == "path" {
+			inputSchema.Required = append(inputSchema.Required, param.Name)
+		}
+		ret.Parameters = append(ret.Parameters, param)
+	}
+	if operation.RequestBody != nil {
+		body, err := s.document.requestBody(operation.RequestBody)
+		if err != nil {
+			return nil, err
+		}
+		contentType, media := selectMediaType(body.Content)
+		ret.ContentType = contentType
+		property := map[string]interface{}{}
+		if media != nil && media.Schema != nil {
+			if property, err = s.document.schema(media.Schema, 0); err != nil {
+				return nil, err
+			}
+		}
+		if body.Description != "" {
+			property["description"] = body.Description
+		}
+		ret.BodyArgument = bodyArgument
+		if _, ok := inputSchema.Properties[ret.BodyArgument]; ok {
+			ret.BodyArgument = fallbackBodyArgument
+		}
+		if _, ok := inputSchema.Properties[ret.BodyArgument]; ok {
+			return nil, fmt.Errorf("%v %v: parameters %v and %v collide with the request body argument", method, aPath, bodyArgument, fallbackBodyArgument)
+		}
+		inputSchema.Properties[ret.BodyArgument] = property
+		if body.Required {
+			inputSchema.Required = append(inputSchema.Required, ret.BodyArgument)
+		}
+	}
+	ret.Metadata = schema.Tool{Name: name, InputSchema: inputSchema}
+	if description != "" {
+		ret.Metadata.Description = &description
+	}
+	return ret, nil
+}
+
+// selectMediaType prefers a JSON media type.
+func selectMediaType(content map[string]*MediaType) (string, *MediaType) {
+	types := make([]string, 0, len(content))
+	for contentType := range content {
+		types = append(types, contentType)
+	}
+	sort.Strings(types)
+	for _, contentType := range types {
+		if isJSON(contentType) {
+			return contentType, content[contentType]
+		}
+	}
+	if len(types) == 0 {
+		return "application/json", nil
+	}
+	return types[0], content[types[0]]
+}
+
+// serverURL returns the operation base URL, resolving relative server URLs against the document location.
+func (s *Service) serverURL(operation *Operation) string {
+	if s.baseURL != "" {
+		return s.baseURL
+	}
+	servers := operation.Servers
+	if len(servers) == 0 {
+		servers = s.document.Servers
+	}
+	if len(servers) == 0 {
+		return ""
+	}
+	URL := servers[0].URL
+	if strings.Contains(URL, "://") {
+		return URL
+	}
+	if scheme := url.Scheme(s.URL, ""); scheme == "http" || scheme == "https" {
+		host := url.Host(s.URL)
+		return scheme + "://" + host + "/" + strings.TrimLeft(URL, "/")
+	}
+	return URL
+}
+
+func isJSON(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	return strings.HasPrefix(contentType, "application/json") || strings.Contains(contentType, "+json")
+}
@@ -0,0 +1,223 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+)
+
+const petstore = `openapi: 3.0.3
+servers:
+  - url: /v1
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets]
+      summary: List pets
+      parameters:
+        - $ref: '#/components/parameters/limit'
+    post:
+      operationId: createPet
+      tags: [pets, admin]
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        schema: {type: integer}
+    get:
+      operationId: getPet
+      tags: [pets]
+      parameters:
+        - name: X-Trace
+          in: header
+          schema: {type: string}
+  /pets/{petId}/notes:
+    post:
+      operationId: addNote
+      parameters:
+        - name: petId
+          in: path
+          schema: {type: integer}
+        - name: body
+          in: query
+          schema: {type: string}
+      requestBody:
+        content:
+          application/json:
+            schema: {type: object}
+components:
+  parameters:
+    limit:
+      name: limit
+      in: query
+      description: max items
+      schema: {type: integer}
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string}
+        tags:
+          type: array
+          items: {type: string}
+`
+
+func TestService(t *testing.T) {
+	var lastRequest *http.Request
+	var lastBody string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest = r
+		data, _ := io.ReadAll(r.Body)
+		lastBody = string(data)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/pets/7/notes":
+			_, _ = w.Write([]byte(`{"format":"` + r.URL.Query().Get("body") + `"}`))
+		case "/v1/pets/large":
+			_, _ = w.Write(make([]byte, 2048))
+		case "/v1/pets/404":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not found"}`))
+		case "/v1/pets":
+			if r.Method == http.MethodPost {
+				_, _ = w.Write(data)
+				return
+			}
+			_, _ = w.Write([]byte(`[{"name":"rex"}]`))
+		default:
+			_, _ = w.Write([]byte(`{"name":"rex","trace":"` + r.Header.Get("X-Trace") + `"}`))
+		}
+	}))
+	defer apiServer.Close()
+	location := filepath.Join(t.TempDir(), "petstore.yaml")
+	if !assert.NoError(t, os.WriteFile(location, []byte(petstore), 0o644)) {
+		return
+	}
+	ctx := context.Background()
+
+	t.Run("filters", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			options []Option
+			expect  []string
+		}{
+			{name: "all", expect: []string{"listPets", "createPet", "getPet", "addNote"}},
+			{name: "exclude tag", options: []Option{WithExcludeTags("admin")}, expect: []string{"listPets", "getPet", "addNote"}},
+			{name: "include operation", options: []Option{WithIncludeOperations("getPet")}, expect: []string{"getPet"}},
+			{name: "include tag and exclude operation", options: []Option{WithIncludeTags("pets"), WithExcludeOperations("listPets")}, expect: []string{"createPet", "getPet"}},
+		}
+		for _, tc := range testCases {
+			service, err := New(ctx, location, tc.options...)
+			if !assert.NoError(t, err, tc.name) {
+				continue
+			}
+			var names []string
+			for _, tool := range service.Tools() {
+				names = append(names, tool.Metadata.Name)
+			}
+			assert.ElementsMatch(t, tc.expect, names, tc.name)
+		}
+	})
+
+	t.Run("parameter declared in two locations", func(t *testing.T) {
+		document := `openapi: 3.0.3
+paths:
+  /items:
+    get:
+      operationId: listItems
+      parameters:
+        - {name: id, in: query, schema: {type: string}}
+        - {name: id, in: header, schema: {type: string}}
+`
+		collision := filepath.Join(t.TempDir(), "collision.yaml")
+		if !assert.NoError(t, os.WriteFile(collision, []byte(document), 0o644)) {
+			return
+		}
+		_, err := New(ctx, collision)
+		assert.Error(t, err)
+	})
+
+	service, err := New(ctx, location, WithBaseURL(apiServer.URL+"/v1"), WithHTTPClient(apiServer.Client()), WithMaxResponseSize(1024))
+	if !assert.NoError(t, err) {
+		return
+	}
+	handler := serverproto.NewDefaultHandler(nil, nil, nil)
+	assert.NoError(t, service.HandlerOption()(handler))
+	for _, tool := range handler.ListRegisteredTools() {
+		if tool.Name == "createPet" {
+			assert.EqualValues(t, []string{"body"}, tool.InputSchema.Required)
+			assert.EqualValues(t, "object", tool.InputSchema.Properties["body"]["type"])
+		}
+		if tool.Name == "addNote" {
+			assert.EqualValues(t, "string", tool.InputSchema.Properties["body"]["type"])
+			assert.EqualValues(t, "object", tool.InputSchema.Properties["requestBody"]["type"])
+		}
+	}
+
+	testCases := []struct {
+		name             string
+		tool             string
+		args             map[string]interface{}
+		expectMethod     string
+		expectURI        string
+		expectBody       string
+		expectStructured map[string]interface{}
+		expectIsError    bool
+		expectErr        bool
+	}{
+		{name: "query parameter", tool: "listPets", args: map[string]interface{}{"limit": float64(10)}, expectMethod: "GET", expectURI: "/v1/pets?limit=10",
+			expectStructured: map[string]interface{}{"result": []interface{}{map[string]interface{}{"name": "rex"}}}},
+		{name: "path and header parameters", tool: "getPet", args: map[string]interface{}{"petId": float64(7), "X-Trace": "abc"}, expectMethod: "GET", expectURI: "/v1/pets/7",
+			expectStructured: map[string]interface{}{"name": "rex", "trace": "abc"}},
+		{name: "request body", tool: "createPet", args: map[string]interface{}{"body": map[string]interface{}{"name": "tom"}}, expectMethod: "POST", expectURI: "/v1/pets",
+			expectBody: `{"name":"tom"}`, expectStructured: map[string]interface{}{"name": "tom"}},
+		{name: "error status", tool: "getPet", args: map[string]interface{}{"petId": "404"}, expectMethod: "GET", expectURI: "/v1/pets/404", expectIsError: true,
+			expectStructured: map[string]interface{}{"error": "not found"}},
+		{name: "body parameter and request body", tool: "addNote", args: map[string]interface{}{"petId": float64(7), "body": "md", "requestBody": map[string]interface{}{"text": "good boy"}}, expectMethod: "POST", expectURI: "/v1/pets/7/notes?body=md",
+			expectBody: `{"text":"good boy"}`, expectStructured: map[string]interface{}{"format": "md"}},
+		{name: "missing path parameter", tool: "getPet", expectErr: true},
+		{name: "response too large", tool: "getPet", args: map[string]interface{}{"petId": "large"}, expectErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lastRequest, lastBody = nil, ""
+			result, rErr := handler.CallTool(ctx, &jsonrpc.TypedRequest[*schema.CallToolRequest]{Request: &schema.CallToolRequest{
+				Params: schema.CallToolRequestParams{Name: tc.tool, Arguments: tc.args},
+			}})
+			if tc.expectErr {
+				assert.NotNil(t, rErr)
+				return
+			}
+			if !assert.Nil(t, rErr) || !assert.NotNil(t, lastRequest) {
+				return
+			}
+			assert.EqualValues(t, tc.expectMethod, lastRequest.Method)
+			assert.EqualValues(t, tc.expectURI, lastRequest.URL.RequestURI())
+			if tc.expectBody != "" {
+				assert.JSONEq(t, tc.expectBody, lastBody)
+			}
+			assert.EqualValues(t, tc.expectIsError, result.IsError != nil && *result.IsError)
+			actual, _ := json.Marshal(result.StructuredContent)
+			expect, _ := json.Marshal(tc.expectStructured)
+			assert.JSONEq(t, string(expect), string(actual))
+		})
+	}
+}
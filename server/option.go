@@ -1,25 +1,93 @@
 package server
 
 import (
+	streamauth "github.com/viant/jsonrpc/transport/server/auth"
 	"github.com/viant/mcp-protocol/schema"
 	"github.com/viant/mcp-protocol/server"
+	"github.com/viant/mcp/server/audit"
 	"github.com/viant/mcp/server/auth"
+	"github.com/viant/mcp/server/completion"
+	"github.com/viant/mcp/server/resource"
+	"github.com/viant/mcp/server/task"
+	"github.com/viant/mcp/server/transcript"
 	"net/http"
+	"strings"
+	"time"
 )
 
 // Option is a function that configures the handler.
 type Option func(s *Server) error
 
-// WithCORS adds a new CORS handler to the handler.
+// WithCORS sets the CORS policy of MCP, REST and catalog endpoints; nil disables CORS headers and origin validation.
 func WithCORS(cors *Cors) Option {
 	return func(s *Server) error {
-		handler := &corsHandler{Cors: cors}
-		s.corsHandler = handler.Middleware
 		s.corsConfig = cors
 		return nil
 	}
 }
 
+// WithRouteCORS sets the CORS policy of the route mounted at path (an MCP endpoint, well-known metadata or a
+// custom handler), overriding the policy set by WithCORS; nil disables CORS for the route.
+func WithRouteCORS(path string, cors *Cors) Option {
+	return func(s *Server) error {
+		if s.routeCors == nil {
+			s.routeCors = make(map[string]*Cors)
+		}
+		s.routeCors[path] = cors
+		return nil
+	}
+}
+
+// WithAllowedHosts sets the Host header allowlist protecting against DNS rebinding; requests for other hosts
+// are rejected with 421. Entries are host names or IPs (any port), host:port pairs or wildcard subdomains
+// (*.example.com); "*" disables validation. By default only loopback names are allowed when the server
+// binds to a loopback address.
+func WithAllowedHosts(hosts ...string) Option {
+	return func(s *Server) error {
+		s.allowedHosts = append([]string{}, hosts...)
+		return nil
+	}
+}
+
+// WithBFFAuthStore sets the grant store backing BFF auth cookies of this server (default in-memory store).
+func WithBFFAuthStore(store streamauth.Store) Option {
+	return func(s *Server) error {
+		s.bffAuth.store = store
+		return nil
+	}
+}
+
+// WithBFFAuthTTL sets the idle and absolute grant TTLs and the rotation grace of the default in-memory store
+// (default 30m, 24h and 2m).
+func WithBFFAuthTTL(idleTTL, maxTTL, rotateGrace time.Duration) Option {
+	return func(s *Server) error {
+		s.bffAuth.idleTTL = idleTTL
+		s.bffAuth.maxTTL = maxTTL
+		s.bffAuth.rotateGrace = rotateGrace
+		return nil
+	}
+}
+
+// WithBFFAuthCookie sets the BFF auth cookie attributes (default BFF-Auth-Session, HttpOnly, SameSite=Lax, Path=/).
+func WithBFFAuthCookie(cookie *auth.BFFCookie) Option {
+	return func(s *Server) error {
+		if cookie == nil {
+			cookie = auth.DefaultBFFCookie()
+		}
+		s.bffAuth.cookie = cookie
+		return nil
+	}
+}
+
+// WithBFFAuthBinder shares the server BFF grant store and cookie with binder, typically the auth.Service whose
+// Middleware is used as authorizer.
+func WithBFFAuthBinder(binder BFFAuthBinder) Option {
+	return func(s *Server) error {
+		s.bffAuth.binders = append(s.bffAuth.binders, binder)
+		return nil
+	}
+}
+
 func WithProtectedResourcesHandler(handler http.HandlerFunc) Option {
 	return func(s *Server) error {
 		s.protectedResourcesHandler = handler
@@ -59,10 +127,11 @@ func WithRootRedirect(enable bool) Option {
 	}
 }
 
-// WithAuthorizer adds a new authorizer to the handler.
+// WithAuthorizer adds a new authorizer to the handler; an auth.Service middleware that is not bound with
+// WithBFFAuthBinder still mints BFF grants in the server store with the server cookie settings.
 func WithAuthorizer(authorizer Middleware) Option {
 	return func(s *Server) error {
-		s.authorizer = authorizer
+		s.authorizer = s.bffAuth.authorizer(authorizer)
 		return nil
 	}
 }
@@ -75,6 +144,134 @@ func WithJRPCAuthorizer(authorizer auth.JRPCAuthorizer) Option {
 	}
 }
 
+// WithListVisibility filters tools/list, resources/list and prompts/list per caller using the supplied visibility.
+func WithListVisibility(visibility *auth.Visibility) Option {
+	return func(s *Server) error {
+		s.visibility = visibility
+		return nil
+	}
+}
+
+// WithAuditor records audited calls (tools/call and resources/read by default) with the supplied auditor.
+func WithAuditor(auditor *audit.Auditor) Option {
+	return func(s *Server) error {
+		s.auditor = auditor
+		return nil
+	}
+}
+
+// WithRecorder records every session's JSON-RPC traffic with the supplied transcript recorder.
+func WithRecorder(recorder *transcript.Recorder) Option {
+	return func(s *Server) error {
+		s.recorder = recorder
+		return nil
+	}
+}
+
+// WithCatalog serves the tool catalog as JSON at uri and as an OpenAPI document at uri + "/openapi.json".
+// Lists are produced for the principal identified by token, or anonymously when token is empty.
+func WithCatalog(uri string, token string) Option {
+	return func(s *Server) error {
+		s.catalogURI = strings.TrimRight(uri, "/")
+		s.catalogToken = token
+		return nil
+	}
+}
+
+// WithREST exposes tools, resources and prompts as plain REST endpoints under prefix:
+// POST {prefix}/tools/{name}, GET {prefix}/resources?uri= and GET {prefix}/prompts/{name}.
+func WithREST(prefix string) Option {
+	return func(s *Server) error {
+		s.restEnabled = true
+		s.restPrefix = strings.TrimRight(prefix, "/")
+		return nil
+	}
+}
+
+// WithToolErrorMapper sets the mapper deciding how tools/call errors are reported; see DefaultToolErrorMapper.
+func WithToolErrorMapper(mapper ToolErrorMapper) Option {
+	return func(s *Server) error {
+		s.toolErrorMapper = mapper
+		return nil
+	}
+}
+
+// WithToolValidation rejects tools/call for tools missing from ListRegisteredTools and arguments violating
+// the registered input schema with a JSON-RPC error before the handler runs; it applies to handlers
+// embedding the protocol DefaultHandler.
+func WithToolValidation() Option {
+	return func(s *Server) error {
+		s.toolValidation = true
+		return nil
+	}
+}
+
+// WithLimits sets request and response size limits, for example DefaultLimits(); nil (the default) disables them.
+func WithLimits(limits *Limits) Option {
+	return func(s *Server) error {
+		s.limits = limits
+		return nil
+	}
+}
+
+// WithCrashReporter sets the reporter of panics recovered in request dispatch; by default panics are
+// logged to the client and written with their stack to stderr.
+func WithCrashReporter(reporter CrashReporter) Option {
+	return func(s *Server) error {
+		s.crashReporter = reporter
+		return nil
+	}
+}
+
+// WithToolHealth rejects calls to a tool after threshold consecutive panics; the tool is retried once
+// cooldown elapses since its last panic (zero cooldown keeps it rejected).
+func WithToolHealth(threshold int, cooldown time.Duration) Option {
+	return func(s *Server) error {
+		s.toolHealth = newToolHealth(threshold, cooldown)
+		return nil
+	}
+}
+
+// WithElicitationFallback sets the fallback used by Client.Elicit when the client did not declare the
+// elicitation capability. When the fallback is also an http.Handler it is mounted at path.
+func WithElicitationFallback(path string, fallback ElicitationFallback) Option {
+	return func(s *Server) error {
+		s.elicitationFallback = fallback
+		if handler, ok := fallback.(http.Handler); ok && path != "" {
+			if s.customHTTPHandlers == nil {
+				s.customHTTPHandlers = make(map[string]http.HandlerFunc)
+			}
+			s.customHTTPHandlers[path] = handler.ServeHTTP
+		}
+		return nil
+	}
+}
+
+// WithCompletions routes completion/complete to the registry and advertises the completions capability.
+func WithCompletions(registry *completion.Registry) Option {
+	return func(s *Server) error {
+		s.completions = registry
+		return nil
+	}
+}
+
+// WithResourceRouter serves resources/read for URIs matching the router templates and adds them to
+// resources/templates/list; other URIs are read by the handler.
+func WithResourceRouter(router *resource.Router) Option {
+	return func(s *Server) error {
+		s.resourceRouter = router
+		return nil
+	}
+}
+
+// WithTasks enables asynchronous tool tasks tracked by manager (tasks/get, tasks/result, tasks/cancel, tasks/list).
+func WithTasks(manager *task.Manager) Option {
+	return func(s *Server) error {
+		s.tasks = manager
+		return nil
+	}
+}
+
 // WithImplementation sets the handler implementation.
 func WithImplementation(implementation schema.Implementation) Option {
 	return func(s *Server) error {
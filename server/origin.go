@@ -6,13 +6,9 @@ import (
 
 // originValidationMiddleware enforces validation of the Origin header on all
 // incoming requests. If the Origin header is present, it must match one of the
-// allowed origins. A wildcard "*" allows any origin.
+// allowed origins or wildcard subdomain patterns. A wildcard "*" allows any origin.
 func originValidationMiddleware(allowed []string) Middleware {
 	return func(next http.Handler) http.Handler {
-		allowedMap := make(map[string]bool, len(allowed))
-		for _, v := range allowed {
-			allowedMap[v] = true
-		}
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			origin := r.Header.Get("Origin")
 			if origin == "" {
@@ -20,8 +16,7 @@ func originValidationMiddleware(allowed []string) Middleware {
 				next.ServeHTTP(w, r)
 				return
 			}
-			// Wildcard permits all origins
-			if allowedMap["*"] || allowedMap[origin] {
+			if originAllowed(allowed, origin) {
 				next.ServeHTTP(w, r)
 				return
 			}
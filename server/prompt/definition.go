@@ -0,0 +1,153 @@
+package prompt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+const frontMatterDelimiter = "---"
+
+// Definition describes a prompt loaded from a file.
+type Definition struct {
+	Name        string      `yaml:"name"`
+	Title       string      `yaml:"title,omitempty"`
+	Description string      `yaml:"description,omitempty"`
+	Arguments   []*Argument `yaml:"arguments,omitempty"`
+	// Body is the text/template body following the front matter.
+	Body string `yaml:"-"`
+	// URL is the location the definition was loaded from.
+	URL string `yaml:"-"`
+
+	template *template.Template
+	marker   string // prefixes body directive lines in the rendered text
+}
+
+// Argument describes a prompt argument.
+type Argument struct {
+	Name        string `yaml:"name"`
+	Title       string `yaml:"title,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	Required    bool   `yaml:"required,omitempty"`
+	Default     string `yaml:"default,omitempty"`
+}
+
+// Decode parses a prompt file; the name defaults to the file name without extension.
+func Decode(URL string, data []byte) (*Definition, error) {
+	ret := &Definition{URL: URL}
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if strings.HasPrefix(text, frontMatterDelimiter+"\n") {
+		rest := text[len(frontMatterDelimiter)+1:]
+		end := strings.Index(rest, "\n"+frontMatterDelimiter)
+		if end == -1 {
+			return nil, fmt.Errorf("invalid prompt %v: unterminated front matter", URL)
+		}
+		if err := yaml.Unmarshal([]byte(rest[:end]), ret); err != nil {
+			return nil, fmt.Errorf("invalid prompt %v front matter: %w", URL, err)
+		}
+		text = strings.TrimPrefix(rest[end+len(frontMatterDelimiter)+1:], "\n")
+	}
+	ret.Body = text
+	if ret.Name == "" {
+		base := path.Base(URL)
+		ret.Name = strings.TrimSuffix(base, path.Ext(base))
+	}
+	if err := ret.Init(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Init validates the definition and parses its body template.
+func (d *Definition) Init() error {
+	if d.Name == "" {
+		return fmt.Errorf("invalid prompt %v: name is required", d.URL)
+	}
+	names := make(map[string]bool, len(d.Arguments))
+	for i, argument := range d.Arguments {
+		if argument == nil || argument.Name == "" {
+			return fmt.Errorf("invalid prompt %v: argument[%d] name is required", d.Name, i)
+		}
+		if names[argument.Name] {
+			return fmt.Errorf("invalid prompt %v: duplicate argument %v", d.Name, argument.Name)
+		}
+		names[argument.Name] = true
+	}
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("invalid prompt %v: %w", d.Name, err)
+	}
+	d.marker = "\x00" + hex.EncodeToString(nonce) + "\x00"
+	var err error
+	if d.template, err = template.New(d.Name).Option("missingkey=zero").Parse(d.markDirectives()); err != nil {
+		return fmt.Errorf("invalid prompt %v template: %w", d.Name, err)
+	}
+	return nil
+}
+
+// markDirectives prefixes role and @resource lines of the body with the marker, so that rendered
+// argument values cannot introduce directives.
+func (d *Definition) markDirectives() string {
+	lines := strings.Split(d.Body, "\n")
+	for i, line := range lines {
+		if roleExpr.MatchString(line) || strings.HasPrefix(line, resourceDirective) {
+			lines[i] = d.marker + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Argument returns the argument with the given name.
+func (d *Definition) Argument(name string) *Argument {
+	for _, argument := range d.Arguments {
+		if argument.Name == name {
+			return argument
+		}
+	}
+	return nil
+}
+
+// Bind validates arguments and returns template data with defaults applied.
+func (d *Definition) Bind(arguments map[string]string) (map[string]string, error) {
+	ret := make(map[string]string, len(d.Arguments))
+	for name := range arguments {
+		if d.Argument(name) == nil {
+			return nil, fmt.Errorf("unknown argument %q", name)
+		}
+	}
+	for _, argument := range d.Arguments {
+		value, ok := arguments[argument.Name]
+		if !ok || value == "" {
+			value = argument.Default
+		}
+		if argument.Required && value == "" {
+			return nil, fmt.Errorf("missing required argument %q", argument.Name)
+		}
+		ret[argument.Name] = value
+	}
+	return ret, nil
+}
+
+// Render executes the body template with data.
+func (d *Definition) Render(data map[string]string) (string, error) {
+	text, err := d.execute(data)
+	if err != nil {
+		return "", err
+	}
+	return strings.ReplaceAll(text, d.marker, ""), nil
+}
+
+// execute executes the body template with data, keeping the directive markers.
+func (d *Definition) execute(data map[string]string) (string, error) {
+	buf := &bytes.Buffer{}
+	if err := d.template.Execute(buf, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt %v: %w", d.Name, err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,31 @@
+// Package prompt serves prompts maintained as template files.
+//
+// Each file starts with YAML front matter declaring the prompt name, title,
+// description and arguments, followed by a text/template body rendered with the
+// prompt arguments as data:
+//
+//	---
+//	name: review
+//	description: Reviews a change
+//	arguments:
+//	  - name: language
+//	    required: true
+//	  - name: focus
+//	    default: correctness
+//	---
+//	Review the following {{.language}} change with a focus on {{.focus}}.
+//	@resource guidelines/{{.language}}.md
+//	[assistant]
+//	I will review it against the {{.language}} guidelines.
+//
+// A line [user] or [assistant] starts a new message with that role; text before the
+// first marker belongs to a user message. A line @resource <uri> embeds the resource
+// content, where relative URIs are resolved against the prompt file location; parent (..)
+// references and resources outside the library URL are rejected. Directives are recognized
+// in the template body only, never in rendered argument values.
+//
+// A Library loads prompt files from a directory or afs URL, registers them on a
+// DefaultHandler via Library.HandlerOption, validates arguments on prompts/get and
+// applies changes to every live handler with a prompts list-changed notification when
+// Reload or Watch detects a modification.
+package prompt
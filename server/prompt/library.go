@@ -0,0 +1,273 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"weak"
+
+	"github.com/viant/afs"
+	"github.com/viant/afs/storage"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+)
+
+// methodPromptsListChanged notifies clients that the prompt list has changed.
+const methodPromptsListChanged = "notifications/prompts/list_changed"
+
+// Library loads prompt files and registers them on handlers.
+type Library struct {
+	URL        string
+	fs         afs.Service
+	extensions []string
+	onError    func(err error)
+
+	mu          sync.RWMutex
+	definitions map[string]*Definition
+	modTimes    map[string]time.Time
+	handlers    []weak.Pointer[serverproto.DefaultHandler]
+}
+
+// New loads prompt files from URL, either a directory or a single file.
+func New(ctx context.Context, URL string, options ...Option) (*Library, error) {
+	ret := &Library{URL: URL, fs: afs.New(), extensions: []string{".md", ".prompt", ".tmpl"}}
+	for _, option := range options {
+		option(ret)
+	}
+	if _, err := ret.Reload(ctx); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Definition returns the prompt definition with the given name.
+func (l *Library) Definition(name string) *Definition {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.definitions[name]
+}
+
+// Definitions returns the prompt definitions sorted by name.
+func (l *Library) Definitions() []*Definition {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	ret := make([]*Definition, 0, len(l.definitions))
+	for _, definition := range l.definitions {
+		ret = append(ret, definition)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name < ret[j].Name })
+	return ret
+}
+
+// Register registers the current prompts on registry; later reloads are not applied.
+func (l *Library) Register(registry *serverproto.Registry) {
+	for _, definition := range l.Definitions() {
+		registry.RegisterPrompts(l.entry(definition))
+	}
+}
+
+// HandlerOption returns a DefaultHandler option registering prompts and applying later reloads.
+func (l *Library) HandlerOption() serverproto.Option {
+	return func(handler *serverproto.DefaultHandler) error {
+		for _, definition := range l.Definitions() {
+			handler.RegisterPrompts(l.entry(definition))
+		}
+		l.mu.Lock()
+		l.handlers = append(l.handlers, weak.Make(handler))
+		l.mu.Unlock()
+		return nil
+	}
+}
+
+// Reload reloads prompt files when any of them has been added, removed or modified since the last load.
+// On error the previous prompts are kept.
+func (l *Library) Reload(ctx context.Context) (bool, error) {
+	objects, err := l.objects(ctx)
+	if err != nil {
+		return false, err
+	}
+	modTimes := make(map[string]time.Time, len(objects))
+	for _, object := range objects {
+		modTimes[object.URL()] = object.ModTime()
+	}
+	l.mu.RLock()
+	modified := l.definitions == nil || !sameModTimes(l.modTimes, modTimes)
+	l.mu.RUnlock()
+	if !modified {
+		return false, nil
+	}
+	definitions := make(map[string]*Definition, len(objects))
+	for _, object := range objects {
+		data, err := l.fs.DownloadWithURL(ctx, object.URL())
+		if err != nil {
+			return false, fmt.Errorf("failed to load prompt %v: %w", object.URL(), err)
+		}
+		definition, err := Decode(object.URL(), data)
+		if err != nil {
+			return false, err
+		}
+		if previous, ok := definitions[definition.Name]; ok {
+			return false, fmt.Errorf("duplicate prompt %v in %v and %v", definition.Name, previous.URL, definition.URL)
+		}
+		definitions[definition.Name] = definition
+	}
+	l.apply(ctx, definitions, modTimes)
+	return true, nil
+}
+
+// Watch reloads prompts every interval until ctx is done.
+func (l *Library) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := l.Reload(ctx); err != nil && l.onError != nil {
+				l.onError(err)
+			}
+		}
+	}
+}
+
+// objects returns prompt files at the library URL.
+func (l *Library) objects(ctx context.Context) ([]storage.Object, error) {
+	object, err := l.fs.Object(ctx, l.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate prompts %v: %w", l.URL, err)
+	}
+	if !object.IsDir() {
+		return []storage.Object{object}, nil
+	}
+	objects, err := l.fs.List(ctx, l.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompts %v: %w", l.URL, err)
+	}
+	var ret []storage.Object
+	for _, candidate := range objects {
+		if candidate.IsDir() || !l.matches(candidate.Name()) {
+			continue
+		}
+		ret = append(ret, candidate)
+	}
+	return ret, nil
+}
+
+func (l *Library) matches(name string) bool {
+	ext := strings.ToLower(path.Ext(name))
+	for _, candidate := range l.extensions {
+		if ext == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+func sameModTimes(previous, current map[string]time.Time) bool {
+	if len(previous) != len(current) {
+		return false
+	}
+	for URL, modTime := range current {
+		if prev, ok := previous[URL]; !ok || !prev.Equal(modTime) {
+			return false
+		}
+	}
+	return true
+}
+
+// apply replaces the definitions and updates prompts of all live handlers.
+func (l *Library) apply(ctx context.Context, definitions map[string]*Definition, modTimes map[string]time.Time) {
+	l.mu.Lock()
+	previous := l.definitions
+	l.definitions = definitions
+	l.modTimes = modTimes
+	var live []weak.Pointer[serverproto.DefaultHandler]
+	var handlers []*serverproto.DefaultHandler
+	for _, pointer := range l.handlers {
+		if handler := pointer.Value(); handler != nil {
+			live = append(live, pointer)
+			handlers = append(handlers, handler)
+		}
+	}
+	l.handlers = live
+	l.mu.Unlock()
+	if previous == nil {
+		return
+	}
+	for _, handler := range handlers {
+		for name := range previous {
+			if _, ok := definitions[name]; !ok {
+				handler.Prompts.Delete(name)
+			}
+		}
+		for _, definition := range definitions {
+			handler.RegisterPrompts(l.entry(definition))
+		}
+		if handler.Notifier != nil {
+			if notification, err := jsonrpc.NewNotification(methodPromptsListChanged, map[string]interface{}{}); err == nil {
+				_ = handler.Notifier.Notify(ctx, notification)
+			}
+		}
+	}
+}
+
+// entry returns the prompt metadata and a handler resolving the definition at call time.
+func (l *Library) entry(definition *Definition) (*schema.Prompt, serverproto.PromptHandlerFunc) {
+	ret := &schema.Prompt{Name: definition.Name}
+	if definition.Title != "" {
+		ret.Title = &definition.Title
+	}
+	if definition.Description != "" {
+		ret.Description = &definition.Description
+	}
+	for _, argument := range definition.Arguments {
+		promptArgument := schema.PromptArgument{Name: argument.Name}
+		if argument.Title != "" {
+			promptArgument.Title = &argument.Title
+		}
+		if argument.Description != "" {
+			promptArgument.Description = &argument.Description
+		}
+		if argument.Required {
+			required := true
+			promptArgument.Required = &required
+		}
+		ret.Arguments = append(ret.Arguments, promptArgument)
+	}
+	name := definition.Name
+	return ret, func(ctx context.Context, request *schema.GetPromptRequestParams) (*schema.GetPromptResult, *jsonrpc.Error) {
+		current := l.Definition(name)
+		if current == nil {
+			return nil, jsonrpc.NewInvalidParamsError(fmt.Sprintf("prompt %v no longer exists", name), nil)
+		}
+		return l.get(ctx, current, request.Arguments)
+	}
+}
+
+// get validates arguments and renders the prompt messages.
+func (l *Library) get(ctx context.Context, definition *Definition, arguments map[string]string) (*schema.GetPromptResult, *jsonrpc.Error) {
+	data, err := definition.Bind(arguments)
+	if err != nil {
+		return nil, jsonrpc.NewInvalidParamsError(err.Error(), nil)
+	}
+	text, err := definition.execute(data)
+	if err != nil {
+		return nil, jsonrpc.NewInternalError(err.Error(), nil)
+	}
+	messages, err := l.messages(ctx, definition, text)
+	if err != nil {
+		return nil, jsonrpc.NewInternalError(err.Error(), nil)
+	}
+	ret := &schema.GetPromptResult{Messages: messages}
+	if definition.Description != "" {
+		description := definition.Description
+		ret.Description = &description
+	}
+	return ret, nil
+}
@@ -0,0 +1,97 @@
+package prompt
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	neturl "net/url"
+	"path"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/url"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+var roleExpr = regexp.MustCompile(`^\[(user|assistant)\]\s*$`)
+
+const resourceDirective = "@resource "
+
+// messages splits rendered text into prompt messages, embedding resources referenced with @resource;
+// only directive lines of the template body, marked by the definition, are interpreted.
+func (l *Library) messages(ctx context.Context, definition *Definition, text string) ([]schema.PromptMessage, error) {
+	var ret []schema.PromptMessage
+	role := schema.RoleUser
+	var lines []string
+	flush := func() {
+		if content := strings.TrimSpace(strings.Join(lines, "\n")); content != "" {
+			ret = append(ret, schema.PromptMessage{Role: role, Content: schema.TextContent{Type: "text", Text: content}})
+		}
+		lines = nil
+	}
+	for _, line := range strings.Split(text, "\n") {
+		directive, ok := strings.CutPrefix(line, definition.marker)
+		if !ok {
+			lines = append(lines, strings.ReplaceAll(line, definition.marker, ""))
+			continue
+		}
+		line = directive
+		if match := roleExpr.FindStringSubmatch(line); match != nil {
+			flush()
+			role = schema.Role(match[1])
+			continue
+		}
+		if strings.HasPrefix(line, resourceDirective) {
+			flush()
+			resource, err := l.embed(ctx, definition, strings.TrimSpace(line[len(resourceDirective):]))
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, schema.PromptMessage{Role: role, Content: resource})
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return ret, nil
+}
+
+// embed loads the resource as embedded content; relative URIs are resolved against the prompt file location
+// and the resource must be located under the library URL.
+func (l *Library) embed(ctx context.Context, definition *Definition, URI string) (*schema.EmbeddedResource, error) {
+	decoded, err := neturl.PathUnescape(URI)
+	if err != nil {
+		decoded = URI
+	}
+	if strings.Contains("/"+URI+"/", "/../") || strings.Contains("/"+decoded+"/", "/../") {
+		return nil, fmt.Errorf("invalid resource %v: parent references are not allowed", URI)
+	}
+	location := URI
+	if !strings.Contains(URI, "://") && !path.IsAbs(URI) {
+		location = url.Join(url.Dir(definition.URL), URI)
+	}
+	root := url.Normalize(l.URL, file.Scheme)
+	if url.Normalize(definition.URL, file.Scheme) == root { // single prompt file library
+		root = url.Dir(root)
+	}
+	if !strings.HasPrefix(url.Normalize(location, file.Scheme), strings.TrimRight(root, "/")+"/") {
+		return nil, fmt.Errorf("invalid resource %v: outside of prompt library %v", URI, l.URL)
+	}
+	data, err := l.fs.DownloadWithURL(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	ret := &schema.EmbeddedResource{Type: "resource", Resource: schema.EmbeddedResourceResource{Uri: URI}}
+	if mimeType := mime.TypeByExtension(path.Ext(location)); mimeType != "" {
+		ret.Resource.MimeType = &mimeType
+	}
+	if utf8.Valid(data) {
+		ret.Resource.Text = string(data)
+	} else {
+		ret.Resource.Blob = base64.StdEncoding.EncodeToString(data)
+	}
+	return ret, nil
+}
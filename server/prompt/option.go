@@ -0,0 +1,37 @@
+package prompt
+
+import (
+	"strings"
+
+	"github.com/viant/afs"
+)
+
+// Option customizes Library.
+type Option func(l *Library)
+
+// WithFS sets the file system used to load prompts and embedded resources.
+func WithFS(fs afs.Service) Option {
+	return func(l *Library) {
+		l.fs = fs
+	}
+}
+
+// WithExtensions sets the prompt file extensions loaded from a directory (default .md, .prompt and .tmpl).
+func WithExtensions(extensions ...string) Option {
+	return func(l *Library) {
+		l.extensions = nil
+		for _, extension := range extensions {
+			if !strings.HasPrefix(extension, ".") {
+				extension = "." + extension
+			}
+			l.extensions = append(l.extensions, strings.ToLower(extension))
+		}
+	}
+}
+
+// WithErrorHandler sets a callback for reload errors reported by Watch.
+func WithErrorHandler(fn func(err error)) Option {
+	return func(l *Library) {
+		l.onError = fn
+	}
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+	"github.com/viant/mcp/server/transcript"
+)
+
+func TestRecorder_Replay(t *testing.T) {
+	newHandler := func(greeting string) serverproto.NewHandler {
+		return serverproto.WithDefaultHandler(context.Background(), func(server *serverproto.DefaultHandler) error {
+			server.RegisterToolWithSchema("greet", "greets", schema.ToolInputSchema{Type: "object"}, nil,
+				func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+					return &schema.CallToolResult{Content: []schema.CallToolResultContentElem{schema.TextContent{Type: "text", Text: greeting}}}, nil
+				})
+			return nil
+		})
+	}
+
+	buffer := &bytes.Buffer{}
+	srv, err := New(WithNewHandler(newHandler("hello")), WithRecorder(transcript.NewRecorder(buffer)))
+	if !assert.NoError(t, err) {
+		return
+	}
+	ctx := context.Background()
+	handler := srv.NewHandler(ctx, nil)
+	requests := []struct {
+		method string
+		params interface{}
+	}{
+		{method: schema.MethodInitialize, params: &schema.InitializeRequestParams{ProtocolVersion: "2025-06-18"}},
+		{method: schema.MethodToolsList, params: &schema.ListToolsRequestParams{}},
+		{method: schema.MethodToolsCall, params: &schema.CallToolRequestParams{Name: "greet"}},
+		{method: schema.MethodToolsCall, params: &schema.CallToolRequestParams{Name: "unknown"}},
+	}
+	for i, item := range requests {
+		request, err := jsonrpc.NewRequest(item.method, item.params)
+		if !assert.NoError(t, err) {
+			return
+		}
+		request.Id = i + 1
+		handler.Serve(ctx, request, &jsonrpc.Response{Id: request.Id, Jsonrpc: jsonrpc.Version})
+	}
+	entries, err := transcript.Load(bytes.NewReader(buffer.Bytes()))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, entries, 2*len(requests))
+
+	testCases := []struct {
+		name        string
+		greeting    string
+		expectDiffs int
+	}{
+		{name: "unchanged server", greeting: "hello", expectDiffs: 0},
+		{name: "changed tool output", greeting: "hi", expectDiffs: 1},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			replayed, err := New(WithNewHandler(newHandler(testCase.greeting)))
+			if !assert.NoError(t, err) {
+				return
+			}
+			report, err := transcript.NewReplayer(replayed.NewHandler).Replay(ctx, entries)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, 1, report.Sessions)
+			assert.Equal(t, len(requests), report.Requests)
+			assert.Len(t, report.Diffs, testCase.expectDiffs, report.String())
+		})
+	}
+}
+
+func TestRecorder_Redaction(t *testing.T) {
+	newHandler := serverproto.WithDefaultHandler(context.Background(), func(server *serverproto.DefaultHandler) error {
+		server.RegisterToolWithSchema("login", "logs in", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				return &schema.CallToolResult{Content: []schema.CallToolResultContentElem{schema.TextContent{Type: "text", Text: "ok"}}}, nil
+			})
+		return nil
+	})
+	testCases := []struct {
+		name         string
+		options      []transcript.Option
+		expectSecret bool
+	}{
+		{name: "default redaction"},
+		{name: "redaction disabled", options: []transcript.Option{transcript.WithRedactor(nil)}, expectSecret: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+			srv, err := New(WithNewHandler(newHandler), WithRecorder(transcript.NewRecorder(buffer, testCase.options...)))
+			if !assert.NoError(t, err) {
+				return
+			}
+			ctx := context.Background()
+			request, err := jsonrpc.NewRequest(schema.MethodToolsCall, map[string]interface{}{
+				"name":      "login",
+				"arguments": map[string]interface{}{"user": "ann", "password": "hunter2"},
+				"_meta":     map[string]interface{}{"progressToken": 5, "authorization": map[string]interface{}{"token": "Bearer eyJ.secret"}},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			request.Id = 1
+			srv.NewHandler(ctx, nil).Serve(ctx, request, &jsonrpc.Response{Id: request.Id, Jsonrpc: jsonrpc.Version})
+			recorded := buffer.String()
+			assert.EqualValues(t, testCase.expectSecret, bytes.Contains(buffer.Bytes(), []byte("hunter2")), recorded)
+			assert.EqualValues(t, testCase.expectSecret, bytes.Contains(buffer.Bytes(), []byte("eyJ.secret")), recorded)
+			assert.Contains(t, recorded, "progressToken")
+			assert.Contains(t, recorded, "ann")
+		})
+	}
+}
@@ -0,0 +1,9 @@
+// Package resource routes resources/read requests by RFC 6570 URI templates.
+//
+// A Router registers resource templates such as repo://{owner}/{name}/file/{+path}
+// with their read handlers. Incoming URIs are matched against the templates and the
+// extracted variables are passed to the handler context, where they can be read with
+// VariablesFromContext and converted with Int, Bool, Float or Bind. The server serves
+// resources/templates/list from the registered templates when configured with
+// server.WithResourceRouter.
+package resource
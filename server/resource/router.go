@@ -0,0 +1,90 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	"github.com/viant/mcp-protocol/server"
+)
+
+// Route represents a resource template with its read handler.
+type Route struct {
+	Metadata schema.ResourceTemplate
+	Template *Template
+	Handler  server.ResourceHandlerFunc
+}
+
+// Router dispatches resources/read requests to handlers of matching resource templates.
+type Router struct {
+	mu     sync.RWMutex
+	routes []*Route
+}
+
+// NewRouter creates a resource template router.
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// Handle registers a handler for the URI template of the resource template metadata.
+func (r *Router) Handle(metadata schema.ResourceTemplate, handler server.ResourceHandlerFunc) error {
+	template, err := Parse(metadata.UriTemplate)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, route := range r.routes {
+		if route.Metadata.UriTemplate == metadata.UriTemplate {
+			r.routes[i] = &Route{Metadata: metadata, Template: template, Handler: handler}
+			return nil
+		}
+	}
+	r.routes = append(r.routes, &Route{Metadata: metadata, Template: template, Handler: handler})
+	return nil
+}
+
+// Templates returns the registered resource templates in registration order.
+func (r *Router) Templates() []schema.ResourceTemplate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ret := make([]schema.ResourceTemplate, 0, len(r.routes))
+	for _, route := range r.routes {
+		ret = append(ret, route.Metadata)
+	}
+	return ret
+}
+
+// Match returns the route matching uri with its variables. When several templates match, the one with
+// the most literal characters wins, then the first registered.
+func (r *Router) Match(uri string) (*Route, Variables, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var ret *Route
+	var variables Variables
+	for _, route := range r.routes {
+		if ret != nil && route.Template.literals <= ret.Template.literals {
+			continue
+		}
+		if matched, ok := route.Template.Match(uri); ok {
+			ret, variables = route, matched
+		}
+	}
+	return ret, variables, ret != nil
+}
+
+// ReadResource reads the resource with the handler of the matching template; variables are available
+// to the handler through VariablesFromContext. ok is false when no template matches.
+func (r *Router) ReadResource(ctx context.Context, request *schema.ReadResourceRequest) (result *schema.ReadResourceResult, ok bool, rpcErr *jsonrpc.Error) {
+	route, variables, ok := r.Match(request.Params.Uri)
+	if !ok {
+		return nil, false, nil
+	}
+	if route.Handler == nil {
+		return nil, true, jsonrpc.NewInternalError(fmt.Sprintf("resource template %v has no handler", route.Metadata.UriTemplate), nil)
+	}
+	result, rpcErr = route.Handler(contextWithVariables(ctx, variables), request)
+	return result, true, rpcErr
+}
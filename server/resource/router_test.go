@@ -0,0 +1,41 @@
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+func TestRouter_ReadResource(t *testing.T) {
+	router := NewRouter()
+	err := router.Handle(schema.ResourceTemplate{Name: "user", UriTemplate: "users://{id}"}, func(ctx context.Context, request *schema.ReadResourceRequest) (*schema.ReadResourceResult, *jsonrpc.Error) {
+		return &schema.ReadResourceResult{Contents: []schema.ReadResourceResultContentsElem{{Uri: request.Params.Uri, Text: VariablesFromContext(ctx)["id"]}}}, nil
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	testCases := []struct {
+		name       string
+		uri        string
+		expectOK   bool
+		expectText string
+	}{
+		{name: "matched", uri: "users://42", expectOK: true, expectText: "42"},
+		{name: "no match", uri: "files://42"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, ok, rpcErr := router.ReadResource(context.Background(), &schema.ReadResourceRequest{Params: schema.ReadResourceRequestParams{Uri: testCase.uri}})
+			assert.Nil(t, rpcErr)
+			assert.Equal(t, testCase.expectOK, ok)
+			if !testCase.expectOK {
+				assert.Nil(t, result)
+				return
+			}
+			assert.Equal(t, testCase.expectText, result.Contents[0].Text)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package resource
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Template represents a parsed RFC 6570 URI template used to match resource URIs.
+// Matching supports simple ({var}), reserved ({+var}), fragment ({#var}), label ({.var}),
+// path segment ({/var}, {/var*}), path parameter ({;var}) and query ({?var}, {&var})
+// expressions; prefix modifiers ({var:3}) are accepted and ignored. Captured values are percent-decoded;
+// a URI whose single-segment variable decodes to a value with a slash or backslash, or to "." or "..", does not match.
+type Template struct {
+	raw      string
+	expr     *regexp.Regexp
+	names    []string // path variables in capture group order
+	segments []bool   // whether the path variable at the same index must decode to a single path segment
+	query    []string // variables read from the query string
+	literals int
+}
+
+type variable struct {
+	name    string
+	explode bool
+}
+
+// Parse parses a URI template.
+func Parse(uriTemplate string) (*Template, error) {
+	ret := &Template{raw: uriTemplate}
+	pattern := strings.Builder{}
+	pattern.WriteString("^")
+	rest := uriTemplate
+	for rest != "" {
+		start := strings.IndexByte(rest, '{')
+		if start == -1 {
+			ret.literal(&pattern, rest)
+			break
+		}
+		ret.literal(&pattern, rest[:start])
+		end := strings.IndexByte(rest[start:], '}')
+		if end == -1 {
+			return nil, fmt.Errorf("invalid uri template %q: unclosed expression", uriTemplate)
+		}
+		if err := ret.expression(&pattern, rest[start+1:start+end]); err != nil {
+			return nil, fmt.Errorf("invalid uri template %q: %w", uriTemplate, err)
+		}
+		rest = rest[start+end+1:]
+	}
+	if len(ret.query) > 0 {
+		pattern.WriteString(`(?:\?[^#]*)?`)
+	}
+	pattern.WriteString("$")
+	var err error
+	if ret.expr, err = regexp.Compile(pattern.String()); err != nil {
+		return nil, fmt.Errorf("invalid uri template %q: %w", uriTemplate, err)
+	}
+	return ret, nil
+}
+
+// String returns the URI template.
+func (t *Template) String() string {
+	return t.raw
+}
+
+// Match returns the variables extracted from uri, or false when uri does not match the template.
+func (t *Template) Match(uri string) (Variables, bool) {
+	matches := t.expr.FindStringSubmatch(uri)
+	if matches == nil {
+		return nil, false
+	}
+	ret := Variables{}
+	for i, name := range t.names {
+		value := matches[i+1]
+		if unescaped, err := url.PathUnescape(value); err == nil {
+			value = unescaped
+		}
+		if t.segments[i] && !isSegment(value) {
+			return nil, false
+		}
+		ret[name] = value
+	}
+	if len(t.query) > 0 {
+		if index := strings.IndexByte(uri, '?'); index != -1 {
+			query := uri[index+1:]
+			if fragment := strings.IndexByte(query, '#'); fragment != -1 {
+				query = query[:fragment]
+			}
+			values, _ := url.ParseQuery(query)
+			for _, name := range t.query {
+				if value, ok := values[name]; ok {
+					ret[name] = strings.Join(value, ",")
+				}
+			}
+		}
+	}
+	return ret, true
+}
+
+func (t *Template) literal(pattern *strings.Builder, text string) {
+	t.literals += len(text)
+	pattern.WriteString(regexp.QuoteMeta(text))
+}
+
+func (t *Template) expression(pattern *strings.Builder, expression string) error {
+	operator := ""
+	if expression != "" && strings.ContainsRune("+#./;?&", rune(expression[0])) {
+		operator, expression = expression[:1], expression[1:]
+	}
+	var variables []variable
+	for _, spec := range strings.Split(expression, ",") {
+		aVariable := variable{name: spec}
+		if strings.HasSuffix(spec, "*") {
+			aVariable = variable{name: strings.TrimSuffix(spec, "*"), explode: true}
+		} else if index := strings.IndexByte(spec, ':'); index != -1 {
+			aVariable.name = spec[:index]
+		}
+		if aVariable.name == "" {
+			return fmt.Errorf("empty variable name")
+		}
+		variables = append(variables, aVariable)
+	}
+	switch operator {
+	case "?", "&":
+		for _, aVariable := range variables {
+			t.query = append(t.query, aVariable.name)
+		}
+		return nil
+	}
+	for i, aVariable := range variables {
+		t.names = append(t.names, aVariable.name)
+		t.segments = append(t.segments, operator != "+" && operator != "#" && !(operator == "/" && aVariable.explode))
+		switch operator {
+		case "":
+			if i > 0 {
+				pattern.WriteString(",")
+			}
+			pattern.WriteString(`([^/?#,]*)`)
+		case "+":
+			if i > 0 {
+				pattern.WriteString(",")
+			}
+			pattern.WriteString(`([^?#]*)`)
+		case "#":
+			if i == 0 {
+				pattern.WriteString(`#`)
+			} else {
+				pattern.WriteString(",")
+			}
+			pattern.WriteString(`(.*)`)
+		case ".":
+			pattern.WriteString(`\.([^/?#.]*)`)
+		case "/":
+			if aVariable.explode {
+				pattern.WriteString(`/([^?#]*)`)
+			} else {
+				pattern.WriteString(`/([^/?#]*)`)
+			}
+		case ";":
+			pattern.WriteString(`;` + regexp.QuoteMeta(aVariable.name) + `=?([^;/?#]*)`)
+		}
+	}
+	return nil
+}
+
+// isSegment returns true when a decoded value cannot escape its path segment.
+func isSegment(value string) bool {
+	return value != "." && value != ".." && !strings.ContainsAny(value, `/\`)
+}
@@ -0,0 +1,66 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplate_Match(t *testing.T) {
+	testCases := []struct {
+		name         string
+		template     string
+		uri          string
+		expectMatch  bool
+		expectValues Variables
+	}{
+		{name: "simple", template: "users://{id}", uri: "users://42", expectMatch: true, expectValues: Variables{"id": "42"}},
+		{name: "simple stops at slash", template: "users://{id}", uri: "users://42/profile"},
+		{name: "reserved path", template: "repo://{owner}/{name}/file/{+path}", uri: "repo://viant/mcp/file/server/handler.go", expectMatch: true, expectValues: Variables{"owner": "viant", "name": "mcp", "path": "server/handler.go"}},
+		{name: "percent decoded", template: "notes://{title}", uri: "notes://hello%20world", expectMatch: true, expectValues: Variables{"title": "hello world"}},
+		{name: "encoded slash rejected", template: "notes://{title}", uri: "notes://a%2Fb"},
+		{name: "encoded dot segment rejected", template: "files://{dir}/{name}", uri: "files://%2E%2E/passwd"},
+		{name: "encoded slash in reserved", template: "repo://{+path}", uri: "repo://a%2Fb", expectMatch: true, expectValues: Variables{"path": "a/b"}},
+		{name: "label", template: "file://{name}{.ext}", uri: "file://report.csv", expectMatch: true, expectValues: Variables{"name": "report", "ext": "csv"}},
+		{name: "path segments", template: "db://{schema}{/table,column}", uri: "db://public/users/email", expectMatch: true, expectValues: Variables{"schema": "public", "table": "users", "column": "email"}},
+		{name: "exploded path", template: "fs://root{/segments*}", uri: "fs://root/a/b/c", expectMatch: true, expectValues: Variables{"segments": "a/b/c"}},
+		{name: "query", template: "search://items{?q,limit}", uri: "search://items?q=go&limit=10", expectMatch: true, expectValues: Variables{"q": "go", "limit": "10"}},
+		{name: "query optional", template: "search://items{?q,limit}", uri: "search://items", expectMatch: true, expectValues: Variables{}},
+		{name: "fragment", template: "doc://{id}{#section}", uri: "doc://7#intro", expectMatch: true, expectValues: Variables{"id": "7", "section": "intro"}},
+		{name: "prefix modifier", template: "color://{hex:6}", uri: "color://ff00aa", expectMatch: true, expectValues: Variables{"hex": "ff00aa"}},
+		{name: "literal mismatch", template: "repo://{owner}/{name}", uri: "git://viant/mcp"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			template, err := Parse(testCase.template)
+			if !assert.NoError(t, err) {
+				return
+			}
+			actual, ok := template.Match(testCase.uri)
+			assert.Equal(t, testCase.expectMatch, ok)
+			if testCase.expectMatch {
+				assert.Equal(t, testCase.expectValues, actual)
+			}
+		})
+	}
+
+	_, err := Parse("repo://{owner")
+	assert.Error(t, err)
+}
+
+func TestVariables_Bind(t *testing.T) {
+	type target struct {
+		Owner string
+		Page  int   `uri:"p"`
+		Draft *bool `uri:"draft"`
+		Tags  []string
+	}
+	actual := &target{}
+	err := Variables{"owner": "viant", "p": "3", "draft": "true", "tags": "a,b"}.Bind(actual)
+	if !assert.NoError(t, err) {
+		return
+	}
+	draft := true
+	assert.Equal(t, &target{Owner: "viant", Page: 3, Draft: &draft, Tags: []string{"a", "b"}}, actual)
+	assert.Error(t, Variables{"p": "x"}.Bind(actual))
+}
@@ -0,0 +1,146 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type variablesKey string
+
+const contextVariablesKey variablesKey = "resource-template-variables"
+
+// Variables holds template variables extracted from a resource URI.
+type Variables map[string]string
+
+// VariablesFromContext returns the template variables of the resource read handled with ctx.
+func VariablesFromContext(ctx context.Context) Variables {
+	ret, _ := ctx.Value(contextVariablesKey).(Variables)
+	return ret
+}
+
+func contextWithVariables(ctx context.Context, variables Variables) context.Context {
+	return context.WithValue(ctx, contextVariablesKey, variables)
+}
+
+// String returns the variable value.
+func (v Variables) String(name string) string {
+	return v[name]
+}
+
+// Int returns the variable value as int.
+func (v Variables) Int(name string) (int, error) {
+	value, ok := v[name]
+	if !ok {
+		return 0, fmt.Errorf("variable %v not found", name)
+	}
+	return strconv.Atoi(value)
+}
+
+// Float returns the variable value as float64.
+func (v Variables) Float(name string) (float64, error) {
+	value, ok := v[name]
+	if !ok {
+		return 0, fmt.Errorf("variable %v not found", name)
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+// Bool returns the variable value as bool.
+func (v Variables) Bool(name string) (bool, error) {
+	value, ok := v[name]
+	if !ok {
+		return false, fmt.Errorf("variable %v not found", name)
+	}
+	return strconv.ParseBool(value)
+}
+
+// Bind assigns variables to the fields of the struct pointed by target. Fields are matched by the
+// uri tag or, without it, by case-insensitive field name; string, bool, integer, float and []string
+// (comma separated) fields are supported.
+func (v Variables) Bind(target interface{}) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expected pointer to struct, but had %T", target)
+	}
+	value = value.Elem()
+	for i := 0; i < value.NumField(); i++ {
+		structField := value.Type().Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+		name := structField.Tag.Get("uri")
+		if name == "-" {
+			continue
+		}
+		text, ok := v[name]
+		if name == "" {
+			text, ok = v.lookupFold(structField.Name)
+		}
+		if !ok {
+			continue
+		}
+		if err := setValue(value.Field(i), text); err != nil {
+			return fmt.Errorf("failed to bind %v: %w", structField.Name, err)
+		}
+	}
+	return nil
+}
+
+func (v Variables) lookupFold(name string) (string, bool) {
+	for key, value := range v {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func setValue(field reflect.Value, text string) error {
+	if field.Kind() == reflect.Ptr {
+		ptr := reflect.New(field.Type().Elem())
+		if err := setValue(ptr.Elem(), text); err != nil {
+			return err
+		}
+		field.Set(ptr)
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(text)
+	case reflect.Bool:
+		value, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		field.SetBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseInt(text, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, err := strconv.ParseUint(text, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(value)
+	case reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(text, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(value)
+	case reflect.Slice:
+		if field.Type() != reflect.TypeOf([]string{}) {
+			return fmt.Errorf("unsupported type %v", field.Type())
+		}
+		field.Set(reflect.ValueOf(strings.Split(text, ",")))
+	default:
+		return fmt.Errorf("unsupported type %v", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+	"github.com/viant/mcp/server/resource"
+)
+
+func TestServer_ResourceRouter(t *testing.T) {
+	router := resource.NewRouter()
+	read := func(ctx context.Context, request *schema.ReadResourceRequest) (*schema.ReadResourceResult, *jsonrpc.Error) {
+		variables := resource.VariablesFromContext(ctx)
+		text := variables.String("owner") + "/" + variables.String("name") + ":" + variables.String("path")
+		return &schema.ReadResourceResult{Contents: []schema.ReadResourceResultContentsElem{{Uri: request.Params.Uri, Text: text}}}, nil
+	}
+	assert.NoError(t, router.Handle(schema.ResourceTemplate{Name: "repo file", UriTemplate: "repo://{owner}/{name}/file/{+path}"}, read))
+	assert.NoError(t, router.Handle(schema.ResourceTemplate{Name: "repo", UriTemplate: "repo://{owner}/{+name}"}, read))
+
+	srv, err := New(WithNewHandler(serverproto.WithDefaultHandler(context.Background())), WithResourceRouter(router))
+	if !assert.NoError(t, err) {
+		return
+	}
+	ctx := context.Background()
+	aClient := srv.InProcessClient(ctx, nil)
+	initialized, err := aClient.Initialize(ctx)
+	if assert.NoError(t, err) {
+		assert.NotNil(t, initialized.Capabilities.Resources)
+	}
+	templates, err := aClient.ListResourceTemplates(ctx, nil)
+	if assert.NoError(t, err) && assert.Len(t, templates.ResourceTemplates, 2) {
+		assert.Equal(t, "repo://{owner}/{name}/file/{+path}", templates.ResourceTemplates[0].UriTemplate)
+	}
+
+	testCases := []struct {
+		name       string
+		uri        string
+		expectText string
+		expectCode int
+	}{
+		{name: "most specific template", uri: "repo://viant/mcp/file/server/handler.go", expectText: "viant/mcp:server/handler.go"},
+		{name: "generic template", uri: "repo://viant/mcp/tree", expectText: "viant/mcp/tree:"},
+		{name: "no template", uri: "file:///etc/hosts", expectCode: schema.ResourceNotFound},
+		{name: "encoded slash in segment", uri: "repo://viant%2F..%2Fetc/mcp/file/passwd", expectCode: schema.ResourceNotFound},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := aClient.ReadResource(ctx, &schema.ReadResourceRequestParams{Uri: testCase.uri})
+			if testCase.expectCode != 0 {
+				rpcError := &jsonrpc.Error{}
+				if assert.ErrorAs(t, err, &rpcError) {
+					assert.Equal(t, testCase.expectCode, rpcError.Code)
+				}
+				return
+			}
+			if assert.NoError(t, err) && assert.Len(t, result.Contents, 1) {
+				assert.Equal(t, testCase.expectText, result.Contents[0].Text)
+			}
+		})
+	}
+}
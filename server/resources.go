@@ -16,6 +16,9 @@ func (h *Handler) ListResources(ctx context.Context, request *jsonrpc.Request) (
 	}
 	id, _ := jsonrpc.AsRequestIntId(request.Id)
 	jRequest := &jsonrpc.TypedRequest[*schema.ListResourcesRequest]{Id: uint64(id), Method: schema.MethodResourcesList, Request: listResourcesRequest}
+	if h.resourceRouter != nil && !h.handler.Implements(schema.MethodResourcesList) {
+		return &schema.ListResourcesResult{Resources: []schema.Resource{}}, nil
+	}
 	return h.handler.ListResources(ctx, jRequest)
 }
 
@@ -27,7 +30,34 @@ func (h *Handler) ListResourceTemplates(ctx context.Context, request *jsonrpc.Re
 	}
 	id, _ := jsonrpc.AsRequestIntId(request.Id)
 	jRequest := &jsonrpc.TypedRequest[*schema.ListResourceTemplatesRequest]{Id: uint64(id), Method: schema.MethodResourcesTemplatesList, Request: listTemplatesRequest}
-	return h.handler.ListResourceTemplates(ctx, jRequest)
+	if h.resourceRouter == nil {
+		return h.handler.ListResourceTemplates(ctx, jRequest)
+	}
+	result := &schema.ListResourceTemplatesResult{ResourceTemplates: []schema.ResourceTemplate{}}
+	if params := listTemplatesRequest.PaginatedRequestParams; params == nil || params.Cursor == nil || *params.Cursor == "" {
+		result.ResourceTemplates = h.resourceRouter.Templates() // routed templates lead the first page
+	}
+	if !h.handler.Implements(schema.MethodResourcesTemplatesList) {
+		return result, nil
+	}
+	handlerResult, err := h.handler.ListResourceTemplates(ctx, jRequest)
+	if err != nil {
+		return nil, err
+	}
+	if handlerResult == nil {
+		return result, nil
+	}
+	routed := map[string]bool{}
+	for _, template := range h.resourceRouter.Templates() {
+		routed[template.UriTemplate] = true
+	}
+	for _, template := range handlerResult.ResourceTemplates {
+		if !routed[template.UriTemplate] {
+			result.ResourceTemplates = append(result.ResourceTemplates, template)
+		}
+	}
+	result.NextCursor = handlerResult.NextCursor
+	return result, nil
 }
 
 // ReadResource handles the resources/read method
@@ -38,6 +68,14 @@ func (h *Handler) ReadResource(ctx context.Context, request *jsonrpc.Request) (*
 	}
 	id, _ := jsonrpc.AsRequestIntId(request.Id)
 	jRequest := &jsonrpc.TypedRequest[*schema.ReadResourceRequest]{Id: uint64(id), Method: schema.MethodResourcesRead, Request: readRequest}
+	if h.resourceRouter != nil {
+		if result, ok, rpcErr := h.resourceRouter.ReadResource(ctx, readRequest); ok {
+			return result, rpcErr
+		}
+		if !h.handler.Implements(schema.MethodResourcesRead) {
+			return nil, schema.NewResourceNotFound(readRequest.Params.Uri)
+		}
+	}
 	return h.handler.ReadResource(ctx, jRequest)
 }
 
@@ -0,0 +1,205 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/viant/jsonrpc"
+	authschema "github.com/viant/mcp-protocol/authorization"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+// nextDetachedRequestID returns a request id for REST and catalog calls; ids are negative so that they never
+// collide with client request ids tracked in activeContexts.
+func (s *Server) nextDetachedRequestID() int {
+	return -int(atomic.AddInt64(&s.detachedRequestSeq, 1))
+}
+
+// detachedHandler creates an initialized handler that is not bound to any transport session; it lives for a
+// single REST call or catalog build, so callers never share handler state.
+func (s *Server) detachedHandler(ctx context.Context, token string) (*Handler, *schema.InitializeResult, error) {
+	handler := s.newHandler(ctx, nil)
+	if handler.err != nil {
+		return nil, nil, handler.err
+	}
+	result := &schema.InitializeResult{}
+	request, err := jsonrpc.NewRequest(schema.MethodInitialize, &schema.InitializeRequestParams{ProtocolVersion: s.protocolVersion})
+	if err != nil {
+		return nil, nil, err
+	}
+	request.Id = s.nextDetachedRequestID()
+	injectAuthMeta(request, token)
+	response := &jsonrpc.Response{}
+	handler.Serve(ctx, request, response)
+	if response.Error != nil {
+		return nil, nil, fmt.Errorf("%v: %w", schema.MethodInitialize, response.Error)
+	}
+	if err = json.Unmarshal(response.Result, result); err != nil {
+		return nil, nil, err
+	}
+	handler.OnNotification(ctx, &jsonrpc.Notification{Jsonrpc: jsonrpc.Version, Method: schema.MethodNotificationInitialized})
+	return handler, result, nil
+}
+
+// restHandler exposes tools, resources and prompts as plain REST endpoints:
+// POST {prefix}/tools/{name}, GET {prefix}/resources?uri= and GET {prefix}/prompts/{name}.
+// Each call is translated into a JSON-RPC request that passes the HTTP auth middleware and Handler.Serve.
+type restHandler struct {
+	server *Server
+	prefix string
+	rpc    http.Handler
+}
+
+func newRESTHandler(s *Server, prefix string, middlewares ...Middleware) *restHandler {
+	ret := &restHandler{server: s, prefix: prefix}
+	ret.rpc = ChainMiddlewareHandlers(http.HandlerFunc(ret.serveRPC), middlewares...)
+	return ret
+}
+
+// ServeHTTP translates a REST call into a JSON-RPC request.
+func (h *restHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request, status, err := h.jsonRPCRequest(r)
+	if err != nil {
+		writeRESTError(w, status, &jsonrpc.Error{Code: jsonrpc.InvalidRequest, Message: err.Error()})
+		return
+	}
+	data, err := json.Marshal(request)
+	if err != nil {
+		writeRESTError(w, http.StatusInternalServerError, jsonrpc.NewInternalError(err.Error(), nil))
+		return
+	}
+	rpcRequest := r.Clone(r.Context())
+	rpcRequest.Method = http.MethodPost
+	rpcRequest.Body = io.NopCloser(bytes.NewReader(data))
+	rpcRequest.ContentLength = int64(len(data))
+	rpcRequest.Header.Set("Content-Type", "application/json")
+	h.rpc.ServeHTTP(w, rpcRequest)
+}
+
+func (h *restHandler) jsonRPCRequest(r *http.Request) (*jsonrpc.Request, int, error) {
+	path := strings.TrimPrefix(r.URL.Path, h.prefix)
+	switch {
+	case strings.HasPrefix(path, "/tools/"):
+		if r.Method != http.MethodPost {
+			return nil, http.StatusMethodNotAllowed, fmt.Errorf("method %v not allowed", r.Method)
+		}
+		arguments := map[string]interface{}{}
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		if len(bytes.TrimSpace(data)) > 0 {
+			if err = json.Unmarshal(data, &arguments); err != nil {
+				return nil, http.StatusBadRequest, fmt.Errorf("invalid JSON arguments: %w", err)
+			}
+		}
+		return h.newRequest(schema.MethodToolsCall, &schema.CallToolRequestParams{Name: strings.TrimPrefix(path, "/tools/"), Arguments: arguments})
+	case path == "/resources":
+		if r.Method != http.MethodGet {
+			return nil, http.StatusMethodNotAllowed, fmt.Errorf("method %v not allowed", r.Method)
+		}
+		uri := r.URL.Query().Get("uri")
+		if uri == "" {
+			return nil, http.StatusBadRequest, fmt.Errorf("uri query parameter is required")
+		}
+		return h.newRequest(schema.MethodResourcesRead, &schema.ReadResourceRequestParams{Uri: uri})
+	case strings.HasPrefix(path, "/prompts/"):
+		if r.Method != http.MethodGet {
+			return nil, http.StatusMethodNotAllowed, fmt.Errorf("method %v not allowed", r.Method)
+		}
+		arguments := map[string]string{}
+		for key, values := range r.URL.Query() {
+			arguments[key] = values[0]
+		}
+		return h.newRequest(schema.MethodPromptsGet, &schema.GetPromptRequestParams{Name: strings.TrimPrefix(path, "/prompts/"), Arguments: arguments})
+	}
+	return nil, http.StatusNotFound, fmt.Errorf("unknown endpoint: %v", r.URL.Path)
+}
+
+func (h *restHandler) newRequest(method string, params interface{}) (*jsonrpc.Request, int, error) {
+	request, err := jsonrpc.NewRequest(method, params)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	request.Id = h.server.nextDetachedRequestID()
+	return request, http.StatusOK, nil
+}
+
+// serveRPC runs the JSON-RPC request on the detached handler of the caller token and writes the REST response.
+func (h *restHandler) serveRPC(w http.ResponseWriter, r *http.Request) {
+	request := &jsonrpc.Request{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeRESTError(w, http.StatusBadRequest, &jsonrpc.Error{Code: jsonrpc.ParseError, Message: err.Error()})
+		return
+	}
+	ctx := r.Context()
+	if ctx.Value(authschema.TokenKey) == nil {
+		if header := strings.TrimSpace(r.Header.Get("Authorization")); strings.HasPrefix(strings.ToLower(header), "bearer ") {
+			ctx = context.WithValue(ctx, authschema.TokenKey, &authschema.Token{Token: header})
+		}
+	}
+	token := ""
+	if value, ok := ctx.Value(authschema.TokenKey).(*authschema.Token); ok && value != nil {
+		token = value.Token
+	}
+	handler, _, err := h.server.detachedHandler(ctx, token)
+	if err != nil {
+		writeRESTError(w, http.StatusInternalServerError, jsonrpc.NewInternalError(err.Error(), nil))
+		return
+	}
+	outcome := &toolOutcome{}
+	ctx = context.WithValue(ctx, toolOutcomeKey, outcome)
+	response := &jsonrpc.Response{}
+	handler.Serve(ctx, request, response)
+	if response.Error != nil {
+		writeRESTError(w, restStatus(response.Error.Code), response.Error)
+		return
+	}
+	status := http.StatusOK
+	if request.Method == schema.MethodToolsCall {
+		status = toolResultStatus(response.Result, outcome)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(response.Result)
+}
+
+// toolResultStatus maps a tool result flagged with isError to an HTTP status: an error converted by the tool
+// error mapper keeps the status of its code, a failure reported by the tool itself maps to 422.
+func toolResultStatus(data []byte, outcome *toolOutcome) int {
+	result := &struct {
+		IsError *bool `json:"isError"`
+	}{}
+	if err := json.Unmarshal(data, result); err != nil || result.IsError == nil || !*result.IsError {
+		return http.StatusOK
+	}
+	if outcome.mapped != nil {
+		return restStatus(outcome.mapped.Code)
+	}
+	return http.StatusUnprocessableEntity
+}
+
+// restStatus maps a JSON-RPC error code to an HTTP status.
+func restStatus(code int) int {
+	switch code {
+	case jsonrpc.MethodNotFound:
+		return http.StatusNotFound
+	case jsonrpc.InvalidParams, jsonrpc.InvalidRequest, jsonrpc.ParseError:
+		return http.StatusBadRequest
+	case schema.Unauthorized:
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
+}
+
+func writeRESTError(w http.ResponseWriter, status int, rpcError *jsonrpc.Error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"error": rpcError})
+}
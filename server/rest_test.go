@@ -0,0 +1,175 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/authorization"
+	"github.com/viant/mcp-protocol/oauth2/meta"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+	"github.com/viant/mcp/server/auth"
+)
+
+func TestServer_REST(t *testing.T) {
+	newHandler := serverproto.WithDefaultHandler(context.Background(), func(server *serverproto.DefaultHandler) error {
+		server.RegisterToolWithSchema("add", "adds numbers", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				a, _ := request.Params.Arguments["a"].(float64)
+				b, _ := request.Params.Arguments["b"].(float64)
+				return &schema.CallToolResult{StructuredContent: map[string]interface{}{"sum": a + b}, Content: []schema.CallToolResultContentElem{}}, nil
+			})
+		server.RegisterToolWithSchema("fail", "reports failure", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				isError := true
+				return &schema.CallToolResult{IsError: &isError, Content: []schema.CallToolResultContentElem{schema.TextContent{Type: "text", Text: "boom"}}}, nil
+			})
+		server.RegisterToolWithSchema("payload", "returns an error shaped payload", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				isError := true
+				return &schema.CallToolResult{IsError: &isError, StructuredContent: map[string]interface{}{"error": true, "code": jsonrpc.MethodNotFound},
+					Content: []schema.CallToolResultContentElem{schema.TextContent{Type: "text", Text: "no route"}}}, nil
+			})
+		server.RegisterToolWithSchema("strict", "rejects arguments", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				return nil, jsonrpc.NewInvalidParamsError("x is required", nil)
+			})
+		server.RegisterToolWithSchema("secret", "protected", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				return &schema.CallToolResult{Content: []schema.CallToolResultContentElem{schema.TextContent{Type: "text", Text: "ok"}}}, nil
+			})
+		server.RegisterResource(schema.Resource{Name: "readme", Uri: "file:///readme.md"}, func(ctx context.Context, request *schema.ReadResourceRequest) (*schema.ReadResourceResult, *jsonrpc.Error) {
+			return &schema.ReadResourceResult{Contents: []schema.ReadResourceResultContentsElem{{Uri: request.Params.Uri, Text: "hello"}}}, nil
+		})
+		server.RegisterPrompts(&schema.Prompt{Name: "greet"}, func(ctx context.Context, request *schema.GetPromptRequestParams) (*schema.GetPromptResult, *jsonrpc.Error) {
+			return &schema.GetPromptResult{Messages: []schema.PromptMessage{{Role: schema.RoleUser, Content: schema.TextContent{Type: "text", Text: "hi " + request.Arguments["name"]}}}}, nil
+		})
+		return nil
+	})
+	authService, err := auth.New(&auth.Config{Policy: &authorization.Policy{
+		Tools: map[string]*authorization.Authorization{"secret": {
+			ProtectedResourceMetadata: &meta.ProtectedResourceMetadata{Resource: "http://localhost", AuthorizationServers: []string{"http://localhost:8096/"}},
+			RequiredScopes:            []string{"read"},
+		}},
+	}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	srv, err := New(WithNewHandler(newHandler), WithREST("/api"), WithAuthorizer(authService.Middleware), WithJRPCAuthorizer(authService.EnsureAuthorized))
+	if !assert.NoError(t, err) {
+		return
+	}
+	httpServer := httptest.NewServer(srv.HTTP(context.Background(), "").Handler)
+	defer httpServer.Close()
+
+	testCases := []struct {
+		name         string
+		method       string
+		path         string
+		body         string
+		token        string
+		expectStatus int
+		expectBody   string
+	}{
+		{name: "tool call", method: http.MethodPost, path: "/api/tools/add", body: `{"a":1,"b":2}`, expectStatus: http.StatusOK, expectBody: `"sum":3`},
+		{name: "tool reported error", method: http.MethodPost, path: "/api/tools/fail", expectStatus: http.StatusUnprocessableEntity, expectBody: "boom"},
+		{name: "tool payload does not set status", method: http.MethodPost, path: "/api/tools/payload", expectStatus: http.StatusUnprocessableEntity, expectBody: "no route"},
+		{name: "tool invalid params", method: http.MethodPost, path: "/api/tools/strict", body: `{}`, expectStatus: http.StatusBadRequest, expectBody: "x is required"},
+		{name: "unknown tool", method: http.MethodPost, path: "/api/tools/missing", expectStatus: http.StatusNotFound},
+		{name: "invalid JSON body", method: http.MethodPost, path: "/api/tools/add", body: `[`, expectStatus: http.StatusBadRequest},
+		{name: "wrong method", method: http.MethodGet, path: "/api/tools/add", expectStatus: http.StatusMethodNotAllowed},
+		{name: "protected tool without token", method: http.MethodPost, path: "/api/tools/secret", expectStatus: http.StatusUnauthorized},
+		{name: "protected tool with token", method: http.MethodPost, path: "/api/tools/secret", token: "abc", expectStatus: http.StatusOK, expectBody: `"ok"`},
+		{name: "resource", method: http.MethodGet, path: "/api/resources?uri=file:///readme.md", expectStatus: http.StatusOK, expectBody: "hello"},
+		{name: "resource without uri", method: http.MethodGet, path: "/api/resources", expectStatus: http.StatusBadRequest},
+		{name: "prompt", method: http.MethodGet, path: "/api/prompts/greet?name=Ada", expectStatus: http.StatusOK, expectBody: "hi Ada"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request, err := http.NewRequest(testCase.method, httpServer.URL+testCase.path, strings.NewReader(testCase.body))
+			if !assert.NoError(t, err) {
+				return
+			}
+			if testCase.token != "" {
+				request.Header.Set("Authorization", "Bearer "+testCase.token)
+			}
+			response, err := http.DefaultClient.Do(request)
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer response.Body.Close()
+			var body json.RawMessage
+			_ = json.NewDecoder(response.Body).Decode(&body)
+			assert.EqualValues(t, testCase.expectStatus, response.StatusCode, string(body))
+			if testCase.expectBody != "" {
+				assert.Contains(t, string(body), testCase.expectBody)
+			}
+		})
+	}
+}
+
+func TestServer_RESTConcurrent(t *testing.T) {
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+	var sessions int32
+	newHandler := serverproto.WithDefaultHandler(context.Background(), func(server *serverproto.DefaultHandler) error {
+		atomic.AddInt32(&sessions, 1)
+		server.RegisterToolWithSchema("wait", "waits for release", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				started <- struct{}{}
+				<-release
+				if request.Params.Arguments["slow"] == true {
+					select {
+					case <-ctx.Done():
+						return nil, jsonrpc.NewInternalError("cancelled", nil)
+					case <-time.After(50 * time.Millisecond):
+					}
+				}
+				return &schema.CallToolResult{Content: []schema.CallToolResultContentElem{schema.TextContent{Type: "text", Text: "done"}}}, nil
+			})
+		return nil
+	})
+	srv, err := New(WithNewHandler(newHandler), WithREST("/api"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	httpServer := httptest.NewServer(srv.HTTP(context.Background(), "").Handler)
+	defer httpServer.Close()
+
+	call := func(body string) int {
+		response, err := http.Post(httpServer.URL+"/api/tools/wait", "application/json", strings.NewReader(body))
+		if err != nil {
+			return 0
+		}
+		_ = response.Body.Close()
+		return response.StatusCode
+	}
+	// the fast call finishes first; with shared request ids its completion cancelled the slow calls
+	var waitGroup sync.WaitGroup
+	statuses := make([]int, 4)
+	for i := range statuses {
+		body := `{"slow":true}`
+		if i == 0 {
+			body = `{}`
+		}
+		waitGroup.Add(1)
+		go func(i int, body string) {
+			defer waitGroup.Done()
+			statuses[i] = call(body)
+		}(i, body)
+		<-started
+	}
+	close(release)
+	waitGroup.Wait()
+	assert.EqualValues(t, []int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusOK}, statuses)
+	assert.EqualValues(t, len(statuses), atomic.LoadInt32(&sessions), "each call runs on its own handler")
+}
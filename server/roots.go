@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/viant/jsonrpc"
+	pclient "github.com/viant/mcp-protocol/client"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+// MethodNotificationRootsListChanged is sent by the client when its roots change.
+const MethodNotificationRootsListChanged = "notifications/roots/list_changed"
+
+type rootsKeyType string
+
+const rootsKey rootsKeyType = "client-roots"
+
+// Roots caches the client roots of a session. Roots are fetched once after initialization and
+// refetched on demand after the client reports a change.
+type Roots struct {
+	client     pclient.Operations
+	mu         sync.Mutex
+	roots      []schema.Root
+	loaded     bool
+	generation int
+}
+
+// NewRoots creates a roots cache backed by client.
+func NewRoots(client pclient.Operations) *Roots {
+	return &Roots{client: client}
+}
+
+// ClientRoots returns the roots cache of the session handling ctx, or nil outside of a request.
+func ClientRoots(ctx context.Context) *Roots {
+	roots, _ := ctx.Value(rootsKey).(*Roots)
+	return roots
+}
+
+// List returns the client roots, calling roots/list only when the cache is empty or invalidated.
+func (r *Roots) List(ctx context.Context) ([]schema.Root, error) {
+	r.mu.Lock()
+	if r.loaded {
+		defer r.mu.Unlock()
+		return r.roots, nil
+	}
+	generation := r.generation
+	r.mu.Unlock()
+	result, err := r.client.ListRoots(ctx, &jsonrpc.TypedRequest[*schema.ListRootsRequest]{Request: &schema.ListRootsRequest{Method: schema.MethodRootsList}})
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if generation == r.generation { // skip caching when roots changed while fetching
+		r.roots, r.loaded = result.Roots, true
+	}
+	return result.Roots, nil
+}
+
+// prefetch loads the roots in the background after initialization.
+func (r *Roots) prefetch() {
+	_, _ = r.List(context.Background())
+}
+
+// Invalidate drops the cached roots so that the next List fetches them again.
+func (r *Roots) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.roots, r.loaded = nil, false
+	r.generation++
+}
+
+// Generation returns the number of changes reported by the client; tools can compare it to detect changes.
+func (r *Roots) Generation() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.generation
+}
+
+// Contains returns true when a file path or URI lies within one of the client roots. Paths are compared
+// lexically after cleaning, so callers should resolve symlinks beforehand if they matter.
+func (r *Roots) Contains(ctx context.Context, location string) (bool, error) {
+	root, err := r.Root(ctx, location)
+	return root != nil, err
+}
+
+// Root returns the client root containing location, or nil when it lies outside of all roots.
+func (r *Roots) Root(ctx context.Context, location string) (*schema.Root, error) {
+	roots, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	candidate := normalizeLocation(location)
+	var ret *schema.Root
+	longest := -1
+	for i, root := range roots {
+		base := strings.TrimSuffix(normalizeLocation(root.Uri), "/")
+		if (candidate == base || strings.HasPrefix(candidate, base+"/")) && len(base) > longest {
+			ret, longest = &roots[i], len(base)
+		}
+	}
+	return ret, nil
+}
+
+// normalizeLocation converts file URIs and relative paths to clean absolute paths; other URIs are
+// returned with a cleaned path.
+func normalizeLocation(location string) string {
+	if !strings.Contains(location, "://") {
+		if abs, err := filepath.Abs(location); err == nil {
+			location = abs
+		}
+		return filepath.ToSlash(filepath.Clean(location))
+	}
+	parsed, err := url.Parse(location)
+	if err != nil {
+		return location
+	}
+	cleaned := "/"
+	if parsed.Path != "" {
+		cleaned = filepath.ToSlash(filepath.Clean(parsed.Path))
+	}
+	if parsed.Scheme == "file" {
+		return cleaned
+	}
+	return parsed.Scheme + "://" + parsed.Host + cleaned
+}
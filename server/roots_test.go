@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+)
+
+type rootsClient struct {
+	Client
+	roots []schema.Root
+	calls int
+}
+
+func (c *rootsClient) ListRoots(ctx context.Context, request *jsonrpc.TypedRequest[*schema.ListRootsRequest]) (*schema.ListRootsResult, *jsonrpc.Error) {
+	c.calls++
+	return &schema.ListRootsResult{Roots: c.roots}, nil
+}
+
+func TestRoots_Contains(t *testing.T) {
+	aClient := &rootsClient{roots: []schema.Root{{Uri: "file:///home/ada/project"}, {Uri: "file:///home/ada/project/vendor/"}}}
+	roots := NewRoots(aClient)
+	testCases := []struct {
+		name       string
+		location   string
+		expect     bool
+		expectRoot string
+	}{
+		{name: "root itself", location: "/home/ada/project", expect: true, expectRoot: "file:///home/ada/project"},
+		{name: "nested path", location: "/home/ada/project/main.go", expect: true, expectRoot: "file:///home/ada/project"},
+		{name: "nested root wins", location: "file:///home/ada/project/vendor/lib/a.go", expect: true, expectRoot: "file:///home/ada/project/vendor/"},
+		{name: "sibling prefix", location: "/home/ada/project-old/main.go"},
+		{name: "parent traversal", location: "/home/ada/project/../secrets.txt"},
+		{name: "other scheme", location: "https://example.com/home/ada/project/main.go"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual, err := roots.Contains(context.Background(), testCase.location)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expect, actual)
+			root, _ := roots.Root(context.Background(), testCase.location)
+			if testCase.expectRoot == "" {
+				assert.Nil(t, root)
+				return
+			}
+			if assert.NotNil(t, root) {
+				assert.Equal(t, testCase.expectRoot, root.Uri)
+			}
+		})
+	}
+	assert.Equal(t, 1, aClient.calls)
+}
+
+func TestHandler_RootsListChanged(t *testing.T) {
+	aClient := &rootsClient{roots: []schema.Root{{Uri: "file:///tmp/a"}}}
+	ctx := context.Background()
+	srv, err := New(WithNewHandler(serverproto.WithDefaultHandler(ctx)))
+	if !assert.NoError(t, err) {
+		return
+	}
+	handler := srv.newHandler(ctx, nil)
+	handler.roots = NewRoots(aClient)
+	contains, _ := handler.roots.Contains(ctx, "/tmp/b/file.txt")
+	assert.False(t, contains)
+
+	aClient.roots = []schema.Root{{Uri: "file:///tmp/b"}}
+	handler.OnNotification(ctx, &jsonrpc.Notification{Jsonrpc: jsonrpc.Version, Method: MethodNotificationRootsListChanged})
+	assert.Equal(t, 1, handler.roots.Generation())
+	contains, _ = handler.roots.Contains(ctx, "/tmp/b/file.txt")
+	assert.True(t, contains)
+	assert.Equal(t, 2, aClient.calls)
+}
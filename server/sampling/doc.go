@@ -0,0 +1,15 @@
+// Package sampling provides a high-level helper for requesting LLM completions from the client
+// (sampling/createMessage) inside server handlers.
+//
+// A Sampler builds the request from plain messages, applies a per-attempt timeout, retries
+// transient failures and returns text or JSON decoded into a Go value:
+//
+//	sampler := sampling.New(server.Client, sampling.WithTimeout(30*time.Second))
+//	summary, err := sampler.Text(ctx, &sampling.Request{
+//		SystemPrompt: "You are a concise assistant.",
+//		Messages:     []schema.SamplingMessage{sampling.UserMessage("Summarize: " + text)},
+//		MaxTokens:    200,
+//	})
+//
+// ErrNotSupported is returned when the client did not declare the sampling capability.
+package sampling
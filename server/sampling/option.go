@@ -0,0 +1,29 @@
+package sampling
+
+import "time"
+
+// Option configures a Sampler.
+type Option func(s *Sampler)
+
+// WithTimeout sets the timeout of a single sampling attempt (default 60s); zero waits for the client indefinitely.
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *Sampler) {
+		s.timeout = timeout
+	}
+}
+
+// WithRetries sets how many times a transient (internal or transport) failure is retried (default 2) and
+// the initial backoff, doubled after each attempt; an attempt timeout is not retried.
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(s *Sampler) {
+		s.retries = retries
+		s.backoff = backoff
+	}
+}
+
+// WithMaxTokens sets the max tokens used when a request does not specify them (default 1024).
+func WithMaxTokens(maxTokens int) Option {
+	return func(s *Sampler) {
+		s.maxTokens = maxTokens
+	}
+}
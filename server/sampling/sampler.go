@@ -0,0 +1,189 @@
+package sampling
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/viant/jsonrpc"
+	pclient "github.com/viant/mcp-protocol/client"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+// ErrNotSupported is returned when the client does not support sampling.
+var ErrNotSupported = errors.New("sampling: client does not support sampling/createMessage")
+
+// Request represents a sampling request.
+type Request struct {
+	Messages         []schema.SamplingMessage
+	SystemPrompt     string
+	ModelPreferences *schema.ModelPreferences
+	MaxTokens        int
+	Temperature      *float64
+	StopSequences    []string
+}
+
+// UserMessage returns a user text message.
+func UserMessage(text string) schema.SamplingMessage {
+	return schema.SamplingMessage{Role: schema.RoleUser, Content: schema.SamplingMessageContent{Type: "text", Text: text}}
+}
+
+// AssistantMessage returns an assistant text message.
+func AssistantMessage(text string) schema.SamplingMessage {
+	return schema.SamplingMessage{Role: schema.RoleAssistant, Content: schema.SamplingMessageContent{Type: "text", Text: text}}
+}
+
+// Hints returns model preferences listing model name hints in order of preference.
+func Hints(names ...string) *schema.ModelPreferences {
+	ret := &schema.ModelPreferences{}
+	for i := range names {
+		ret.Hints = append(ret.Hints, schema.ModelHint{Name: &names[i]})
+	}
+	return ret
+}
+
+// Sampler requests completions from the client.
+type Sampler struct {
+	client    pclient.Operations
+	timeout   time.Duration
+	retries   int
+	backoff   time.Duration
+	maxTokens int
+}
+
+// New creates a sampler using the client operations of a server handler.
+func New(client pclient.Operations, options ...Option) *Sampler {
+	ret := &Sampler{client: client, timeout: time.Minute, retries: 2, backoff: 500 * time.Millisecond, maxTokens: 1024}
+	for _, option := range options {
+		option(ret)
+	}
+	return ret
+}
+
+// Supported returns true when the client declared the sampling capability.
+func (s *Sampler) Supported() bool {
+	return s.client != nil && s.client.Implements(schema.MethodSamplingCreateMessage)
+}
+
+// Create sends the sampling request, retrying transient failures.
+func (s *Sampler) Create(ctx context.Context, request *Request) (*schema.CreateMessageResult, error) {
+	if !s.Supported() {
+		return nil, ErrNotSupported
+	}
+	params := s.params(request)
+	backoff := s.backoff
+	var err error
+	for attempt := 0; ; attempt++ {
+		var result *schema.CreateMessageResult
+		if result, err = s.attempt(ctx, params); err == nil {
+			return result, nil
+		}
+		if attempt >= s.retries || !isTransient(ctx, err) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return nil, err
+}
+
+// Text returns the text of the sampled message.
+func (s *Sampler) Text(ctx context.Context, request *Request) (string, error) {
+	result, err := s.Create(ctx, request)
+	if err != nil {
+		return "", err
+	}
+	if result.Content.Type != "text" {
+		return "", fmt.Errorf("sampling: expected text content, but had %v", result.Content.Type)
+	}
+	return result.Content.Text, nil
+}
+
+// JSON samples a JSON response and decodes it into T. For struct types the expected JSON schema is
+// appended to the system prompt; markdown code fences around the response are ignored.
+func JSON[T any](ctx context.Context, sampler *Sampler, request *Request) (*T, error) {
+	var ret T
+	prompted := *request
+	instruction := "Respond only with JSON, without any commentary."
+	outputSchema := &schema.ToolOutputSchema{}
+	if err := outputSchema.Load(&ret); err == nil && len(outputSchema.Properties) > 0 {
+		if data, err := json.Marshal(outputSchema); err == nil {
+			instruction = "Respond only with JSON matching this schema, without any commentary: " + string(data)
+		}
+	}
+	prompted.SystemPrompt = strings.TrimSpace(request.SystemPrompt + "\n" + instruction)
+	text, err := sampler.Text(ctx, &prompted)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal([]byte(stripCodeFence(text)), &ret); err != nil {
+		return nil, fmt.Errorf("sampling: failed to decode JSON response: %w", err)
+	}
+	return &ret, nil
+}
+
+func (s *Sampler) params(request *Request) *schema.CreateMessageRequestParams {
+	ret := &schema.CreateMessageRequestParams{
+		Messages:         request.Messages,
+		MaxTokens:        request.MaxTokens,
+		ModelPreferences: request.ModelPreferences,
+		Temperature:      request.Temperature,
+		StopSequences:    request.StopSequences,
+	}
+	if ret.MaxTokens == 0 {
+		ret.MaxTokens = s.maxTokens
+	}
+	if request.SystemPrompt != "" {
+		ret.SystemPrompt = &request.SystemPrompt
+	}
+	return ret
+}
+
+// attempt sends one request bounded by the sampler timeout; the client operations must honor ctx cancellation.
+func (s *Sampler) attempt(ctx context.Context, params *schema.CreateMessageRequestParams) (*schema.CreateMessageResult, error) {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+	request := &jsonrpc.TypedRequest[*schema.CreateMessageRequest]{Request: &schema.CreateMessageRequest{Method: schema.MethodSamplingCreateMessage, Params: *params}}
+	result, err := s.client.CreateMessage(ctx, request)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("sampling: %w", ctx.Err())
+		}
+		return nil, err
+	}
+	if result == nil {
+		return nil, errors.New("sampling: empty result")
+	}
+	return result, nil
+}
+
+// isTransient returns true for internal (transport) errors; timeouts and cancellations are not retried.
+func isTransient(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var rpcError *jsonrpc.Error
+	return errors.As(err, &rpcError) && rpcError.Code == jsonrpc.InternalError
+}
+
+func stripCodeFence(text string) string {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "```") {
+		return text
+	}
+	text = strings.TrimPrefix(text, "```")
+	if index := strings.Index(text, "\n"); index != -1 {
+		text = text[index+1:]
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text), "```"))
+}
@@ -0,0 +1,139 @@
+package sampling
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+type fakeClient struct {
+	mu        sync.Mutex
+	supported bool
+	replies   []func(ctx context.Context) (*schema.CreateMessageResult, *jsonrpc.Error)
+	calls     int
+	requests  []*schema.CreateMessageRequest
+}
+
+func (c *fakeClient) Notify(ctx context.Context, notification *jsonrpc.Notification) error {
+	return nil
+}
+func (c *fakeClient) NextRequestID() jsonrpc.RequestId                                  { return 1 }
+func (c *fakeClient) LastRequestID() jsonrpc.RequestId                                  { return 1 }
+func (c *fakeClient) Init(ctx context.Context, capabilities *schema.ClientCapabilities) {}
+func (c *fakeClient) Implements(method string) bool {
+	return c.supported && method == schema.MethodSamplingCreateMessage
+}
+func (c *fakeClient) ListRoots(ctx context.Context, request *jsonrpc.TypedRequest[*schema.ListRootsRequest]) (*schema.ListRootsResult, *jsonrpc.Error) {
+	return nil, jsonrpc.NewMethodNotFound("roots", nil)
+}
+func (c *fakeClient) Elicit(ctx context.Context, request *jsonrpc.TypedRequest[*schema.ElicitRequest]) (*schema.ElicitResult, *jsonrpc.Error) {
+	return nil, jsonrpc.NewMethodNotFound("elicit", nil)
+}
+func (c *fakeClient) CreateMessage(ctx context.Context, request *jsonrpc.TypedRequest[*schema.CreateMessageRequest]) (*schema.CreateMessageResult, *jsonrpc.Error) {
+	c.mu.Lock()
+	c.requests = append(c.requests, request.Request)
+	reply := c.replies[c.calls]
+	c.calls++
+	c.mu.Unlock()
+	return reply(ctx)
+}
+
+func textReply(text string) func(ctx context.Context) (*schema.CreateMessageResult, *jsonrpc.Error) {
+	return func(ctx context.Context) (*schema.CreateMessageResult, *jsonrpc.Error) {
+		return &schema.CreateMessageResult{Role: schema.RoleAssistant, Model: "test", Content: schema.CreateMessageResultContent{Type: "text", Text: text}}, nil
+	}
+}
+
+func errorReply(err *jsonrpc.Error) func(ctx context.Context) (*schema.CreateMessageResult, *jsonrpc.Error) {
+	return func(ctx context.Context) (*schema.CreateMessageResult, *jsonrpc.Error) {
+		return nil, err
+	}
+}
+
+func hangReply(ctx context.Context) (*schema.CreateMessageResult, *jsonrpc.Error) {
+	<-ctx.Done() // never replies; the transport gives up when ctx is done
+	return nil, jsonrpc.NewInternalError(ctx.Err().Error(), nil)
+}
+
+func TestSampler_Text(t *testing.T) {
+	testCases := []struct {
+		name        string
+		client      *fakeClient
+		expectText  string
+		expectErr   error
+		expectCalls int
+	}{
+		{name: "text", client: &fakeClient{supported: true, replies: []func(ctx context.Context) (*schema.CreateMessageResult, *jsonrpc.Error){textReply("hello")}}, expectText: "hello", expectCalls: 1},
+		{name: "not supported", client: &fakeClient{}, expectErr: ErrNotSupported},
+		{name: "transient failure retried", client: &fakeClient{supported: true, replies: []func(ctx context.Context) (*schema.CreateMessageResult, *jsonrpc.Error){
+			errorReply(jsonrpc.NewInternalError("connection reset", nil)), textReply("again"),
+		}}, expectText: "again", expectCalls: 2},
+		{name: "timeout not retried", client: &fakeClient{supported: true, replies: []func(ctx context.Context) (*schema.CreateMessageResult, *jsonrpc.Error){
+			hangReply, textReply("late"),
+		}}, expectErr: context.DeadlineExceeded, expectCalls: 1},
+		{name: "rejection not retried", client: &fakeClient{supported: true, replies: []func(ctx context.Context) (*schema.CreateMessageResult, *jsonrpc.Error){
+			errorReply(jsonrpc.NewInvalidRequest("user rejected sampling", nil)), textReply("never"),
+		}}, expectErr: &jsonrpc.Error{}, expectCalls: 1},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			sampler := New(testCase.client, WithTimeout(20*time.Millisecond), WithRetries(1, time.Millisecond))
+			text, err := sampler.Text(context.Background(), &Request{SystemPrompt: "be brief", Messages: []schema.SamplingMessage{UserMessage("hi")}})
+			switch expected := testCase.expectErr.(type) {
+			case nil:
+				assert.NoError(t, err)
+			case *jsonrpc.Error:
+				assert.ErrorAs(t, err, &expected)
+			default:
+				assert.True(t, errors.Is(err, expected), err)
+			}
+			assert.Equal(t, testCase.expectText, text)
+			testCase.client.mu.Lock()
+			defer testCase.client.mu.Unlock()
+			assert.Equal(t, testCase.expectCalls, testCase.client.calls)
+			if testCase.expectCalls > 0 {
+				params := testCase.client.requests[0].Params
+				assert.Equal(t, 1024, params.MaxTokens)
+				assert.Equal(t, "be brief", *params.SystemPrompt)
+			}
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type Sentiment struct {
+		Label string  `json:"label"`
+		Score float64 `json:"score"`
+	}
+	testCases := []struct {
+		name      string
+		reply     string
+		expect    *Sentiment
+		expectErr bool
+	}{
+		{name: "plain JSON", reply: `{"label":"positive","score":0.9}`, expect: &Sentiment{Label: "positive", Score: 0.9}},
+		{name: "fenced JSON", reply: "```json\n{\"label\":\"negative\",\"score\":0.2}\n```", expect: &Sentiment{Label: "negative", Score: 0.2}},
+		{name: "not JSON", reply: "I think it is positive", expectErr: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			client := &fakeClient{supported: true, replies: []func(ctx context.Context) (*schema.CreateMessageResult, *jsonrpc.Error){textReply(testCase.reply)}}
+			actual, err := JSON[Sentiment](context.Background(), New(client), &Request{Messages: []schema.SamplingMessage{UserMessage("great product")}})
+			if testCase.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, testCase.expect, actual)
+			assert.Contains(t, *client.requests[0].Params.SystemPrompt, `"label"`)
+		})
+	}
+}
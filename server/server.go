@@ -4,11 +4,18 @@ import (
 	"context"
 	"errors"
 	"github.com/viant/jsonrpc/transport"
+	pclient "github.com/viant/mcp-protocol/client"
 	"github.com/viant/mcp-protocol/schema"
 	"github.com/viant/mcp-protocol/server"
 	"github.com/viant/mcp-protocol/syncmap"
 	"github.com/viant/mcp/client"
+	"github.com/viant/mcp/server/audit"
 	"github.com/viant/mcp/server/auth"
+	"github.com/viant/mcp/server/completion"
+	"github.com/viant/mcp/server/inprocess"
+	"github.com/viant/mcp/server/resource"
+	"github.com/viant/mcp/server/task"
+	"github.com/viant/mcp/server/transcript"
 	"net/http"
 )
 
@@ -21,10 +28,30 @@ type Server struct {
 	protocolVersion           string
 	loggerName                string
 	protectedResourcesHandler http.HandlerFunc
-	corsHandler               func(next http.Handler) http.Handler
 	corsConfig                *Cors
+	routeCors                 map[string]*Cors
+	allowedHosts              []string
+	bffAuth                   *bffAuth
 	authorizer                func(next http.Handler) http.Handler
 	jRPCAuthorizer            auth.JRPCAuthorizer
+	visibility                *auth.Visibility
+	auditor                   *audit.Auditor
+	recorder                  *transcript.Recorder
+	tasks                     *task.Manager
+	toolErrorMapper           ToolErrorMapper
+	toolValidation            bool
+	oversize                  *oversizeMarks
+	crashReporter             CrashReporter
+	toolHealth                *toolHealth
+	limits                    *Limits
+	elicitationFallback       ElicitationFallback
+	completions               *completion.Registry
+	resourceRouter            *resource.Router
+	catalogURI                string
+	catalogToken              string
+	restEnabled               bool
+	restPrefix                string
+	detachedRequestSeq        int64
 	stdioServer
 	httpServer
 }
@@ -38,6 +65,10 @@ func (s *Server) CancelOperation(id int) {
 
 // NewHandler creates a new handler instance
 func (s *Server) NewHandler(ctx context.Context, transport transport.Transport) transport.Handler {
+	if s.recorder != nil {
+		session := s.recorder.Session()
+		return session.Handler(s.newHandler(ctx, session.Transport(transport)))
+	}
 	handler := s.newHandler(ctx, transport)
 	return handler
 }
@@ -52,6 +83,8 @@ func (s *Server) newHandler(ctx context.Context, transport transport.Transport)
 	ret.Logger = NewLogger(ret.loggerName, &ret.loggingLevel, ret.Notifier)
 
 	aClient := NewClient(ret.clientFeatures, transport)
+	aClient.fallback = s.elicitationFallback
+	ret.roots = NewRoots(aClient)
 	ret.handler, ret.err = s.newServer(ctx, transport, ret.Logger, aClient)
 	return ret
 }
@@ -63,10 +96,21 @@ func (s *Server) AsClient(ctx context.Context) client.Interface {
 	return NewAdapter(handler)
 }
 
+// InProcessClient returns a client connected to this server over a full-duplex in-process transport.
+// Unlike AsClient, server-to-client requests (elicitation, sampling, roots) and notifications (logging,
+// progress) are delivered to handler, which may be nil when the client does not serve them.
+func (s *Server) InProcessClient(ctx context.Context, handler pclient.Handler, options ...client.Option) *client.Client {
+	clientHandler := client.NewHandler(handler)
+	options = append([]client.Option{client.WithNotificationHandler(clientHandler)}, options...)
+	if handler != nil {
+		options = append([]client.Option{client.WithClientHandler(handler)}, options...)
+	}
+	aTransport := inprocess.New(ctx, s.NewHandler, clientHandler)
+	return client.New(s.info.Name+"-client", s.info.Version, aTransport, options...)
+}
+
 // New creates a new Server instance
 func New(options ...Option) (*Server, error) {
-	dCors := defaultCors()
-	corsHandler := &corsHandler{dCors}
 	// initialize handler
 	s := &Server{
 		info: schema.Implementation{
@@ -76,8 +120,9 @@ func New(options ...Option) (*Server, error) {
 		loggerName:      "handler",
 		protocolVersion: schema.LatestProtocolVersion,
 		activeContexts:  syncmap.NewMap[int, *activeContext](),
-		corsHandler:     corsHandler.Middleware,
-		corsConfig:      dCors,
+		corsConfig:      defaultCors(),
+		oversize:        newOversizeMarks(),
+		bffAuth:         defaultBFFAuth(),
 	}
 	for _, option := range options {
 		if err := option(s); err != nil {
@@ -1,15 +1,163 @@
 package server
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/viant/jsonrpc"
 	"github.com/viant/jsonrpc/transport/server/stdio"
 )
 
+// methodOversizeRequest names the placeholder of a stdio line exceeding Limits.MaxBodyBytes; Serve rejects
+// the placeholder by its id recorded in oversizeMarks, never by the method name a client could send.
+const methodOversizeRequest = "$/oversizeRequest"
+
+// oversizeMarks records ids of stdio requests replaced by lineLimitReader.
+type oversizeMarks struct {
+	mu  sync.Mutex
+	ids map[string]int
+}
+
+func newOversizeMarks() *oversizeMarks {
+	return &oversizeMarks{ids: map[string]int{}}
+}
+
+// mark records a replaced request id.
+func (m *oversizeMarks) mark(id jsonrpc.RequestId) {
+	key, err := json.Marshal(id)
+	if err != nil {
+		return
+	}
+	m.mu.Lock()
+	m.ids[string(key)]++
+	m.mu.Unlock()
+}
+
+// take returns true and forgets id when it was recorded by mark.
+func (m *oversizeMarks) take(id jsonrpc.RequestId) bool {
+	if m == nil || id == nil {
+		return false
+	}
+	key, err := json.Marshal(id)
+	if err != nil {
+		return false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.ids[string(key)] == 0 {
+		return false
+	}
+	if m.ids[string(key)]--; m.ids[string(key)] == 0 {
+		delete(m.ids, string(key))
+	}
+	return true
+}
+
 type stdioServer struct {
 	stdioServerOption []stdio.Option
 }
 
-// Stdio return stdio handler
+// Stdio return stdio handler; stdin lines are bounded by Limits.MaxBodyBytes unless a reader option is set.
 func (s *Server) Stdio(ctx context.Context) *stdio.Server {
-	return stdio.New(ctx, s.NewHandler, s.stdioServerOption...)
+	var options []stdio.Option
+	if s.limits != nil && s.limits.MaxBodyBytes > 0 {
+		options = append(options, stdio.WithReader(newLineLimitReader(os.Stdin, s.limits.MaxBodyBytes, s.oversize)))
+	}
+	return stdio.New(ctx, s.NewHandler, append(options, s.stdioServerOption...)...)
+}
+
+// lineLimitReader passes newline delimited messages up to limit bytes; a longer message is discarded and
+// replaced by a methodOversizeRequest request with the message id marked in marks, or dropped when no id
+// precedes the limit.
+type lineLimitReader struct {
+	closer  io.Closer
+	reader  *bufio.Reader
+	limit   int64
+	marks   *oversizeMarks
+	pending []byte
+	err     error
+}
+
+func newLineLimitReader(reader io.ReadCloser, limit int64, marks *oversizeMarks) *lineLimitReader {
+	return &lineLimitReader{closer: reader, reader: bufio.NewReader(reader), limit: limit, marks: marks}
+}
+
+// Read reads the current line.
+func (r *lineLimitReader) Read(data []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		r.pending, r.err = r.readLine()
+	}
+	n := copy(data, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// Close closes the underlying reader.
+func (r *lineLimitReader) Close() error {
+	return r.closer.Close()
+}
+
+// readLine reads the next line, replacing it when it exceeds the limit.
+func (r *lineLimitReader) readLine() ([]byte, error) {
+	var line []byte
+	for {
+		fragment, err := r.reader.ReadSlice('\n')
+		if int64(len(line)+len(fragment)) <= r.limit {
+			line = append(line, fragment...)
+		} else if int64(len(line)) < r.limit {
+			line = append(line, fragment[:r.limit-int64(len(line))]...)
+		}
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue
+		}
+		if int64(len(line)) >= r.limit && !bytes.HasSuffix(line, []byte{'\n'}) {
+			return r.oversizeRequest(line), err
+		}
+		return line, err
+	}
+}
+
+// oversizeRequest marks and returns the replacement of a truncated message, or nil when its id is unknown.
+func (r *lineLimitReader) oversizeRequest(prefix []byte) []byte {
+	id := messageID(prefix)
+	if id == nil {
+		return nil
+	}
+	data, err := json.Marshal(&jsonrpc.Request{Jsonrpc: jsonrpc.Version, Id: id, Method: methodOversizeRequest})
+	if err != nil {
+		return nil
+	}
+	r.marks.mark(id)
+	return append(data, '\n')
+}
+
+// messageID returns the top-level id of a possibly truncated JSON object.
+func messageID(data []byte) jsonrpc.RequestId {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil
+	}
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return nil
+		}
+		var value interface{}
+		if err = decoder.Decode(&value); err != nil {
+			return nil
+		}
+		if key == "id" {
+			return value
+		}
+	}
+	return nil
 }
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/viant/jsonrpc"
+	"github.com/viant/jsonrpc/transport"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+// PartialContentMetaKey carries streamed tool content chunks in notifications/progress _meta.
+const PartialContentMetaKey = "io.viant.mcp/partial-content"
+
+type streamKey string
+
+const contentStreamKey streamKey = "content-stream"
+
+// contentStream collects content emitted by a running tool call and forwards it to the client.
+type contentStream struct {
+	notifier transport.Notifier
+	token    *schema.ProgressToken
+	mu       sync.Mutex
+	chunks   []schema.CallToolResultContentElem
+}
+
+// streamContext binds a content stream for the tools/call request to ctx.
+func (h *Handler) streamContext(ctx context.Context, request *jsonrpc.Request) (context.Context, *contentStream) {
+	stream := &contentStream{notifier: h.Notifier, token: extractProgressToken(request)}
+	return context.WithValue(ctx, contentStreamKey, stream), stream
+}
+
+// ContentStreamed returns true when EmitContent delivers chunks while the tool runs, that is within a
+// tools/call request carrying a progress token; otherwise chunks only reach the final tool result.
+func ContentStreamed(ctx context.Context) bool {
+	stream, _ := ctx.Value(contentStreamKey).(*contentStream)
+	return stream != nil && stream.token != nil && stream.notifier != nil
+}
+
+// EmitContent streams content chunks of the running tool call to the client as notifications/progress
+// tied to the request progress token. Emitted chunks are also prepended to the final tool result, so
+// clients that do not listen for partial output still receive the whole output.
+// Outside of a tools/call request EmitContent is a no-op.
+func EmitContent(ctx context.Context, content ...schema.CallToolResultContentElem) error {
+	stream, _ := ctx.Value(contentStreamKey).(*contentStream)
+	if stream == nil || len(content) == 0 {
+		return nil
+	}
+	stream.mu.Lock()
+	stream.chunks = append(stream.chunks, content...)
+	progress := len(stream.chunks)
+	stream.mu.Unlock()
+	if stream.token == nil || stream.notifier == nil {
+		return nil
+	}
+	notification, err := jsonrpc.NewNotification(schema.MethodNotificationProgress, &schema.ProgressNotificationParams{
+		ProgressToken: *stream.token,
+		Progress:      float64(progress),
+		Meta:          map[string]interface{}{PartialContentMetaKey: content},
+	})
+	if err != nil {
+		return err
+	}
+	return stream.notifier.Notify(ctx, notification)
+}
+
+// EmitText streams a text chunk of the running tool call.
+func EmitText(ctx context.Context, text string) error {
+	return EmitContent(ctx, schema.TextContent{Type: "text", Text: text})
+}
+
+// aggregate prepends the emitted chunks to the final result content.
+func (s *contentStream) aggregate(result *schema.CallToolResult) *schema.CallToolResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.chunks) == 0 || result == nil {
+		return result
+	}
+	content := make([]schema.CallToolResultContentElem, 0, len(s.chunks)+len(result.Content))
+	content = append(content, s.chunks...)
+	result.Content = append(content, result.Content...)
+	return result
+}
+
+// callTool runs tools/call with a content stream bound to ctx; errors are mapped with the tool error mapper.
+func (h *Handler) callTool(ctx context.Context, request *jsonrpc.Request) (*schema.CallToolResult, *jsonrpc.Error) {
+	name := toolName(request)
+	cause := &toolErrorCause{}
+	ctx = context.WithValue(ctx, toolErrorCauseKey, cause)
+	if !h.toolHealth.healthy(name) {
+		return h.mapToolError(ctx, request, jsonrpc.NewInternalError(fmt.Sprintf("tool %v is unhealthy after repeated panics", name), nil), nil)
+	}
+	ctx, stream := h.streamContext(ctx, request)
+	result, err := h.CallTool(ctx, request)
+	h.toolHealth.succeeded(name)
+	if err != nil {
+		if result, err = h.mapToolError(ctx, request, err, cause.err); err != nil {
+			return nil, err
+		}
+	}
+	return stream.aggregate(result), nil
+}
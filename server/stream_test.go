@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+	"github.com/viant/mcp/client"
+	"github.com/viant/mcp/server/inprocess"
+)
+
+func TestServer_EmitContent(t *testing.T) {
+	newHandler := serverproto.WithDefaultHandler(context.Background(), func(server *serverproto.DefaultHandler) error {
+		server.RegisterToolWithSchema("tail", "streams lines", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				for _, line := range []string{"line 1", "line 2", "line 3"} {
+					if err := EmitText(ctx, line); err != nil {
+						return nil, jsonrpc.NewInternalError(err.Error(), nil)
+					}
+				}
+				return &schema.CallToolResult{Content: []schema.CallToolResultContentElem{schema.TextContent{Type: "text", Text: "done"}}}, nil
+			})
+		return nil
+	})
+	srv, err := New(WithNewHandler(newHandler))
+	if !assert.NoError(t, err) {
+		return
+	}
+	ctx := context.Background()
+
+	testCases := []struct {
+		name         string
+		listen       bool
+		noHandler    bool
+		unbound      bool
+		expectChunks []string
+	}{
+		{name: "chunks delivered to listener", listen: true, expectChunks: []string{"line 1", "line 2", "line 3"}},
+		{name: "no listener"},
+		{name: "client without handler", listen: true, noHandler: true, expectChunks: []string{"line 1", "line 2", "line 3"}},
+		{name: "notification handler not bound", listen: true, unbound: true, expectChunks: []string{"line 1", "line 2", "line 3", "done"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var aClient *client.Client
+			switch {
+			case testCase.unbound:
+				aClient = client.New("test", "1.0", inprocess.New(ctx, srv.NewHandler, nil))
+			case testCase.noHandler:
+				aClient = srv.InProcessClient(ctx, nil)
+			default:
+				aClient = srv.InProcessClient(ctx, &inProcessClientHandler{})
+			}
+			var mu sync.Mutex
+			var chunks []string
+			var options []client.RequestOption
+			if testCase.listen {
+				options = append(options, client.WithContentListener(func(ctx context.Context, content []schema.CallToolResultContentElem) {
+					mu.Lock()
+					defer mu.Unlock()
+					for _, item := range content {
+						if text, ok := item.(map[string]interface{})["text"].(string); ok {
+							chunks = append(chunks, text)
+						}
+					}
+				}))
+			}
+			result, err := aClient.CallTool(ctx, &schema.CallToolRequestParams{Name: "tail"}, options...)
+			if !assert.NoError(t, err) {
+				return
+			}
+			mu.Lock()
+			assert.EqualValues(t, testCase.expectChunks, chunks)
+			mu.Unlock()
+			data, _ := json.Marshal(result.Content)
+			assert.Equal(t, `[{"text":"line 1","type":"text"},{"text":"line 2","type":"text"},{"text":"line 3","type":"text"},{"text":"done","type":"text"}]`, string(data))
+		})
+	}
+}
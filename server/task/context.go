@@ -0,0 +1,86 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+type contextKey string
+
+const (
+	bindingKey  contextKey = "task-binding"
+	progressKey contextKey = "task-progress"
+	panicKey    contextKey = "task-panic"
+)
+
+// PanicHandler converts a panic recovered from a task function into the task error.
+type PanicHandler func(ctx context.Context, value interface{}) *jsonrpc.Error
+
+// WithPanicHandler sets the handler of panics raised by tasks started with ctx.
+func WithPanicHandler(ctx context.Context, handler PanicHandler) context.Context {
+	return context.WithValue(ctx, panicKey, handler)
+}
+
+// binding lets tools started within a request detach into a task.
+type binding struct {
+	manager  *Manager
+	owner    string
+	listener Listener
+}
+
+// NewContext binds the manager and task owner to ctx so that tools can call Async.
+func NewContext(ctx context.Context, manager *Manager, owner string, listener Listener) context.Context {
+	return context.WithValue(ctx, bindingKey, &binding{manager: manager, owner: owner, listener: listener})
+}
+
+// Async runs fn as a task and returns immediately with a result referencing the task.
+// Without a task manager bound to ctx, fn runs synchronously.
+func Async(ctx context.Context, fn Func) (*schema.CallToolResult, *jsonrpc.Error) {
+	aBinding, _ := ctx.Value(bindingKey).(*binding)
+	if aBinding == nil {
+		return fn(ctx)
+	}
+	task, err := aBinding.manager.Start(ctx, aBinding.owner, nil, fn, aBinding.listener)
+	if err != nil {
+		return nil, jsonrpc.NewInternalError(err.Error(), nil)
+	}
+	return &schema.CallToolResult{
+		Meta: map[string]interface{}{RelatedTaskMetaKey: map[string]interface{}{"taskId": task.TaskId}},
+		Content: []schema.CallToolResultContentElem{
+			schema.TextContent{Type: "text", Text: fmt.Sprintf("task %v started; poll tasks/get and fetch tasks/result", task.TaskId)},
+		},
+	}, nil
+}
+
+type reporter struct {
+	manager  *Manager
+	id       string
+	listener Listener
+}
+
+// ReportStatus updates the status message of the task running with ctx; it is a no-op outside a task.
+func ReportStatus(ctx context.Context, message string) {
+	aReporter, _ := ctx.Value(progressKey).(*reporter)
+	if aReporter == nil {
+		return
+	}
+	task, err := aReporter.manager.update(ctx, aReporter.id, func(entry *Entry) {
+		if !entry.Terminal() {
+			entry.Task.StatusMessage = &message
+		}
+	})
+	if err == nil && aReporter.listener != nil {
+		aReporter.listener(ctx, task)
+	}
+}
+
+// ID returns the id of the task running with ctx, or an empty string.
+func ID(ctx context.Context) string {
+	if aReporter, _ := ctx.Value(progressKey).(*reporter); aReporter != nil {
+		return aReporter.id
+	}
+	return ""
+}
@@ -0,0 +1,14 @@
+// Package task runs long-running tool calls asynchronously.
+//
+// A Manager starts a tool call in the background and tracks its state
+// (working, completed, failed, cancelled) together with status messages and the
+// final CallToolResult in a Store. MemoryStore is the default store; expired
+// tasks are removed lazily by Start and List at most once per cleanup interval,
+// or explicitly by Manager.Cleanup or the Manager.Run janitor.
+//
+// The server exposes tasks through the MCP task methods (tasks/get, tasks/result,
+// tasks/cancel and tasks/list) when configured with server.WithTasks. A client may
+// request task execution by setting the task field of tools/call parameters, or a
+// tool may detach itself by returning Async(ctx, fn), which answers immediately
+// with a result referencing the task through the related-task _meta key.
+package task
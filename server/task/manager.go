@@ -0,0 +1,298 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+const (
+	// MethodGet returns task state.
+	MethodGet = "tasks/get"
+	// MethodResult waits for and returns the task result.
+	MethodResult = "tasks/result"
+	// MethodCancel cancels a task.
+	MethodCancel = "tasks/cancel"
+	// MethodList lists tasks of the caller.
+	MethodList = "tasks/list"
+	// MethodNotificationStatus notifies about task status changes.
+	MethodNotificationStatus = "notifications/tasks/status"
+	// RelatedTaskMetaKey references a task from a result _meta.
+	RelatedTaskMetaKey = "io.modelcontextprotocol/related-task"
+)
+
+// ErrNotFound is returned for unknown, expired or foreign tasks.
+var ErrNotFound = errors.New("task not found")
+
+// Func executes the tool call of a task.
+type Func func(ctx context.Context) (*schema.CallToolResult, *jsonrpc.Error)
+
+// Listener is notified about task status changes.
+type Listener func(ctx context.Context, task *schema.Task)
+
+// Manager runs tasks and tracks their state.
+type Manager struct {
+	store        Store
+	defaultTTL   time.Duration
+	maxTTL       time.Duration
+	pollInterval time.Duration
+	mu           sync.Mutex
+	running      map[string]*execution
+
+	cleanupInterval time.Duration
+	cleanupMu       sync.Mutex
+	lastCleanup     time.Time
+}
+
+type execution struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New creates a task manager.
+func New(options ...Option) *Manager {
+	ret := &Manager{
+		store:        NewMemoryStore(),
+		defaultTTL:   time.Hour,
+		maxTTL:       24 * time.Hour,
+		pollInterval: time.Second,
+		running:      map[string]*execution{},
+
+		cleanupInterval: time.Minute,
+	}
+	for _, option := range options {
+		option(ret)
+	}
+	return ret
+}
+
+// Start creates a task for owner and runs fn in the background, detached from ctx cancellation.
+// ttl is the requested retention in milliseconds; nil uses the default.
+func (m *Manager) Start(ctx context.Context, owner string, ttl *int, fn Func, listener Listener) (*schema.Task, error) {
+	m.sweep(ctx)
+	retention := m.defaultTTL
+	if ttl != nil && *ttl > 0 {
+		retention = time.Duration(*ttl) * time.Millisecond
+	}
+	if m.maxTTL > 0 && retention > m.maxTTL {
+		retention = m.maxTTL
+	}
+	now := time.Now().UTC()
+	pollInterval := int(m.pollInterval.Milliseconds())
+	entry := &Entry{
+		Owner:     owner,
+		ExpiresAt: now.Add(retention),
+		Task: schema.Task{
+			TaskId:        uuid.NewString(),
+			Status:        schema.TaskStatusWorking,
+			CreatedAt:     now.Format(time.RFC3339Nano),
+			LastUpdatedAt: now.Format(time.RFC3339Nano),
+			Ttl:           int(retention.Milliseconds()),
+			PollInterval:  &pollInterval,
+		},
+	}
+	if err := m.store.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	exec := &execution{cancel: cancel, done: make(chan struct{})}
+	m.mu.Lock()
+	m.running[entry.Task.TaskId] = exec
+	m.mu.Unlock()
+	runCtx = context.WithValue(runCtx, progressKey, &reporter{manager: m, id: entry.Task.TaskId, listener: listener})
+	go m.run(runCtx, entry.Task.TaskId, fn, listener, exec)
+	ret := entry.Task
+	return &ret, nil
+}
+
+func (m *Manager) run(ctx context.Context, id string, fn Func, listener Listener, exec *execution) {
+	defer func() {
+		exec.cancel()
+		m.mu.Lock()
+		delete(m.running, id)
+		m.mu.Unlock()
+		close(exec.done)
+	}()
+	var result *schema.CallToolResult
+	var rpcErr *jsonrpc.Error
+	func() {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			if handler, _ := ctx.Value(panicKey).(PanicHandler); handler != nil {
+				rpcErr = handler(ctx, r)
+				return
+			}
+			rpcErr = jsonrpc.NewInternalError(fmt.Sprintf("task panicked: %v", r), nil)
+		}()
+		result, rpcErr = fn(ctx)
+	}()
+	task, err := m.update(context.WithoutCancel(ctx), id, func(entry *Entry) {
+		if entry.Terminal() { // cancelled meanwhile
+			return
+		}
+		switch {
+		case rpcErr != nil:
+			entry.Task.Status = schema.TaskStatusFailed
+			entry.Task.StatusMessage = &rpcErr.Message
+			entry.Error = rpcErr
+		case ctx.Err() != nil:
+			entry.Task.Status = schema.TaskStatusCancelled
+		default:
+			entry.Task.Status = schema.TaskStatusCompleted
+			if result != nil && result.IsError != nil && *result.IsError {
+				entry.Task.Status = schema.TaskStatusFailed
+			}
+			entry.Result = result
+		}
+	})
+	if err == nil && listener != nil {
+		listener(context.WithoutCancel(ctx), task)
+	}
+}
+
+// update applies fn to the stored entry.
+func (m *Manager) update(ctx context.Context, id string, fn func(entry *Entry)) (*schema.Task, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, err := m.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, ErrNotFound
+	}
+	fn(entry)
+	entry.Task.LastUpdatedAt = time.Now().UTC().Format(time.RFC3339Nano)
+	if err = m.store.Put(ctx, entry); err != nil {
+		return nil, err
+	}
+	return &entry.Task, nil
+}
+
+// Get returns the task entry owned by owner.
+func (m *Manager) Get(ctx context.Context, owner, id string) (*Entry, error) {
+	entry, err := m.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil || entry.Owner != owner || (!entry.ExpiresAt.IsZero() && time.Now().After(entry.ExpiresAt)) {
+		return nil, ErrNotFound
+	}
+	return entry, nil
+}
+
+// Await blocks until the task reaches a terminal state or ctx is done.
+func (m *Manager) Await(ctx context.Context, owner, id string) (*Entry, error) {
+	for {
+		entry, err := m.Get(ctx, owner, id)
+		if err != nil || entry.Terminal() {
+			return entry, err
+		}
+		m.mu.Lock()
+		exec := m.running[id]
+		m.mu.Unlock()
+		var wait <-chan struct{}
+		if exec != nil {
+			wait = exec.done
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-wait:
+		case <-time.After(m.pollInterval):
+		}
+	}
+}
+
+// Cancel cancels a running task.
+func (m *Manager) Cancel(ctx context.Context, owner, id string) (*schema.Task, error) {
+	entry, err := m.Get(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Terminal() {
+		return nil, fmt.Errorf("task %v is already %v", id, entry.Task.Status)
+	}
+	task, err := m.update(ctx, id, func(entry *Entry) {
+		entry.Task.Status = schema.TaskStatusCancelled
+	})
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	exec := m.running[id]
+	m.mu.Unlock()
+	if exec != nil {
+		exec.cancel()
+	}
+	return task, nil
+}
+
+// List returns unexpired tasks of owner.
+func (m *Manager) List(ctx context.Context, owner string) ([]schema.Task, error) {
+	m.sweep(ctx)
+	entries, err := m.store.List(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	ret := make([]schema.Task, 0, len(entries))
+	for _, entry := range entries {
+		if entry.ExpiresAt.IsZero() || now.Before(entry.ExpiresAt) {
+			ret = append(ret, entry.Task)
+		}
+	}
+	return ret, nil
+}
+
+// Cleanup removes expired tasks, cancelling them when still running.
+func (m *Manager) Cleanup(ctx context.Context) (int, error) {
+	now := time.Now()
+	m.mu.Lock()
+	for id, exec := range m.running {
+		if entry, _ := m.store.Get(ctx, id); entry != nil && !entry.ExpiresAt.IsZero() && now.After(entry.ExpiresAt) {
+			exec.cancel()
+		}
+	}
+	m.mu.Unlock()
+	return m.store.DeleteExpired(ctx, now)
+}
+
+// sweep runs Cleanup when the cleanup interval elapsed since the previous sweep.
+func (m *Manager) sweep(ctx context.Context) {
+	if m.cleanupInterval <= 0 {
+		return
+	}
+	now := time.Now()
+	m.cleanupMu.Lock()
+	if now.Sub(m.lastCleanup) < m.cleanupInterval {
+		m.cleanupMu.Unlock()
+		return
+	}
+	m.lastCleanup = now
+	m.cleanupMu.Unlock()
+	_, _ = m.Cleanup(context.WithoutCancel(ctx))
+}
+
+// Run removes expired tasks every interval until ctx is done.
+func (m *Manager) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, _ = m.Cleanup(ctx)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package task
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+func TestManager_Start(t *testing.T) {
+	testCases := []struct {
+		name         string
+		fn           Func
+		expectStatus schema.TaskStatus
+	}{
+		{name: "completed", fn: func(ctx context.Context) (*schema.CallToolResult, *jsonrpc.Error) {
+			return &schema.CallToolResult{}, nil
+		}, expectStatus: schema.TaskStatusCompleted},
+		{name: "tool error result", fn: func(ctx context.Context) (*schema.CallToolResult, *jsonrpc.Error) {
+			isError := true
+			return &schema.CallToolResult{IsError: &isError}, nil
+		}, expectStatus: schema.TaskStatusFailed},
+		{name: "rpc error", fn: func(ctx context.Context) (*schema.CallToolResult, *jsonrpc.Error) {
+			return nil, jsonrpc.NewInternalError("boom", nil)
+		}, expectStatus: schema.TaskStatusFailed},
+		{name: "panic", fn: func(ctx context.Context) (*schema.CallToolResult, *jsonrpc.Error) {
+			panic("boom")
+		}, expectStatus: schema.TaskStatusFailed},
+	}
+	ctx := context.Background()
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			manager := New()
+			started, err := manager.Start(ctx, "owner", nil, testCase.fn, nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+			entry, err := manager.Await(ctx, "owner", started.TaskId)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, testCase.expectStatus, entry.Task.Status)
+			_, err = manager.Get(ctx, "other", started.TaskId)
+			assert.ErrorIs(t, err, ErrNotFound)
+		})
+	}
+}
+
+func TestManager_Cleanup(t *testing.T) {
+	ctx := context.Background()
+	manager := New(WithTTL(time.Millisecond, time.Hour))
+	started, err := manager.Start(ctx, "owner", nil, func(ctx context.Context) (*schema.CallToolResult, *jsonrpc.Error) {
+		<-ctx.Done()
+		return nil, nil
+	}, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	time.Sleep(5 * time.Millisecond)
+	removed, err := manager.Cleanup(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	_, err = manager.Get(ctx, "owner", started.TaskId)
+	assert.ErrorIs(t, err, ErrNotFound)
+	tasks, err := manager.List(ctx, "owner")
+	assert.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func TestManager_LazyCleanup(t *testing.T) {
+	testCases := []struct {
+		name            string
+		cleanupInterval time.Duration
+		expectRemoved   bool
+	}{
+		{name: "cleanup on access", cleanupInterval: time.Nanosecond, expectRemoved: true},
+		{name: "lazy cleanup disabled", cleanupInterval: 0, expectRemoved: false},
+	}
+	ctx := context.Background()
+	noop := func(ctx context.Context) (*schema.CallToolResult, *jsonrpc.Error) {
+		return &schema.CallToolResult{}, nil
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := NewMemoryStore()
+			manager := New(WithStore(store), WithTTL(time.Millisecond, time.Hour), WithCleanupInterval(testCase.cleanupInterval))
+			started, err := manager.Start(ctx, "owner", nil, noop, nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+			_, _ = manager.Await(ctx, "owner", started.TaskId)
+			time.Sleep(5 * time.Millisecond)
+			_, err = manager.List(ctx, "owner")
+			assert.NoError(t, err)
+			entry, err := store.Get(ctx, started.TaskId)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectRemoved, entry == nil)
+		})
+	}
+}
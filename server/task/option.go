@@ -0,0 +1,35 @@
+package task
+
+import "time"
+
+// Option customizes Manager.
+type Option func(m *Manager)
+
+// WithStore sets the task store (default MemoryStore).
+func WithStore(store Store) Option {
+	return func(m *Manager) {
+		m.store = store
+	}
+}
+
+// WithTTL sets the default and maximum task retention measured from creation (default 1h and 24h).
+func WithTTL(defaultTTL, maxTTL time.Duration) Option {
+	return func(m *Manager) {
+		m.defaultTTL = defaultTTL
+		m.maxTTL = maxTTL
+	}
+}
+
+// WithPollInterval sets the poll interval suggested to clients (default 1s).
+func WithPollInterval(interval time.Duration) Option {
+	return func(m *Manager) {
+		m.pollInterval = interval
+	}
+}
+
+// WithCleanupInterval sets how often Start and List remove expired tasks (default 1m); zero disables lazy cleanup.
+func WithCleanupInterval(interval time.Duration) Option {
+	return func(m *Manager) {
+		m.cleanupInterval = interval
+	}
+}
@@ -0,0 +1,116 @@
+package task
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+// Entry represents a tracked task.
+type Entry struct {
+	Task schema.Task `json:"task"`
+	// Owner identifies the session or principal allowed to access the task.
+	Owner     string                 `json:"owner,omitempty"`
+	Result    *schema.CallToolResult `json:"result,omitempty"`
+	Error     *jsonrpc.Error         `json:"error,omitempty"`
+	ExpiresAt time.Time              `json:"expiresAt"`
+}
+
+// Terminal returns true when the task reached a final state.
+func (e *Entry) Terminal() bool {
+	return IsTerminal(e.Task.Status)
+}
+
+// IsTerminal returns true for completed, failed and cancelled statuses.
+func IsTerminal(status schema.TaskStatus) bool {
+	switch status {
+	case schema.TaskStatusCompleted, schema.TaskStatusFailed, schema.TaskStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// Store persists task entries.
+type Store interface {
+	// Put creates or replaces an entry.
+	Put(ctx context.Context, entry *Entry) error
+	// Get returns an entry or nil when it does not exist.
+	Get(ctx context.Context, id string) (*Entry, error)
+	// List returns entries of owner.
+	List(ctx context.Context, owner string) ([]*Entry, error)
+	// Delete removes an entry.
+	Delete(ctx context.Context, id string) error
+	// DeleteExpired removes entries expired at now and returns their number.
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
+
+// MemoryStore keeps entries in memory.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	entries map[string]*Entry
+}
+
+// NewMemoryStore creates an in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: map[string]*Entry{}}
+}
+
+// Put stores a copy of entry.
+func (s *MemoryStore) Put(ctx context.Context, entry *Entry) error {
+	clone := *entry
+	s.mu.Lock()
+	s.entries[entry.Task.TaskId] = &clone
+	s.mu.Unlock()
+	return nil
+}
+
+// Get returns a copy of the entry.
+func (s *MemoryStore) Get(ctx context.Context, id string) (*Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.entries[id]
+	if !ok {
+		return nil, nil
+	}
+	clone := *entry
+	return &clone, nil
+}
+
+// List returns copies of owner entries.
+func (s *MemoryStore) List(ctx context.Context, owner string) ([]*Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var ret []*Entry
+	for _, entry := range s.entries {
+		if entry.Owner == owner {
+			clone := *entry
+			ret = append(ret, &clone)
+		}
+	}
+	return ret, nil
+}
+
+// Delete removes an entry.
+func (s *MemoryStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	delete(s.entries, id)
+	s.mu.Unlock()
+	return nil
+}
+
+// DeleteExpired removes expired entries.
+func (s *MemoryStore) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := 0
+	for id, entry := range s.entries {
+		if !entry.ExpiresAt.IsZero() && now.After(entry.ExpiresAt) {
+			delete(s.entries, id)
+			count++
+		}
+	}
+	return count, nil
+}
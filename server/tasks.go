@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	"github.com/viant/mcp/internal/sessionid"
+	"github.com/viant/mcp/server/task"
+)
+
+// taskOwner returns the identity tasks are bound to: the transport session, or this handler when detached.
+func (h *Handler) taskOwner(ctx context.Context) string {
+	if owner := sessionid.FromContext(ctx); owner != "" {
+		return owner
+	}
+	return fmt.Sprintf("handler-%p", h)
+}
+
+// taskContext binds the task manager so that tools can detach with task.Async; task panics are reported as crashes of request.
+func (h *Handler) taskContext(ctx context.Context, request *jsonrpc.Request) context.Context {
+	if h.tasks == nil {
+		return ctx
+	}
+	ctx = task.WithPanicHandler(ctx, h.recoverTask(request))
+	return task.NewContext(ctx, h.tasks, h.taskOwner(ctx), h.notifyTaskStatus)
+}
+
+// notifyTaskStatus sends notifications/tasks/status to the session.
+func (h *Handler) notifyTaskStatus(ctx context.Context, aTask *schema.Task) {
+	if h.Notifier == nil || aTask == nil {
+		return
+	}
+	notification, err := jsonrpc.NewNotification(task.MethodNotificationStatus, aTask)
+	if err != nil {
+		return
+	}
+	_ = h.Notifier.Notify(ctx, notification)
+}
+
+// isTaskRequest returns true when tools/call parameters request task execution.
+func isTaskRequest(request *jsonrpc.Request) bool {
+	params := &struct {
+		Task *schema.TaskMetadata `json:"task"`
+	}{}
+	return json.Unmarshal(request.Params, params) == nil && params.Task != nil
+}
+
+// CallToolTask starts tools/call as a task and returns the task handle.
+func (h *Handler) CallToolTask(ctx context.Context, request *jsonrpc.Request) (*schema.CreateTaskResult, *jsonrpc.Error) {
+	params := &schema.CallToolRequestParams{}
+	if err := json.Unmarshal(request.Params, params); err != nil {
+		return nil, jsonrpc.NewInvalidParamsError(fmt.Sprintf("failed to parse: %v", err), request.Params)
+	}
+	owner := h.taskOwner(ctx)
+	ctx = task.WithPanicHandler(ctx, h.recoverTask(request))
+	aTask, err := h.tasks.Start(ctx, owner, params.Task.Ttl, func(ctx context.Context) (*schema.CallToolResult, *jsonrpc.Error) {
+		return h.callTool(ctx, request)
+	}, h.notifyTaskStatus)
+	if err != nil {
+		return nil, jsonrpc.NewInternalError(err.Error(), nil)
+	}
+	return &schema.CreateTaskResult{Task: *aTask}, nil
+}
+
+// GetTask handles the tasks/get method.
+func (h *Handler) GetTask(ctx context.Context, request *jsonrpc.Request) (*schema.Task, *jsonrpc.Error) {
+	params := &schema.GetTaskRequestParams{}
+	if err := json.Unmarshal(request.Params, params); err != nil {
+		return nil, jsonrpc.NewInvalidParamsError(fmt.Sprintf("failed to parse: %v", err), request.Params)
+	}
+	entry, err := h.tasks.Get(ctx, h.taskOwner(ctx), params.TaskId)
+	if err != nil {
+		return nil, taskError(err)
+	}
+	return &entry.Task, nil
+}
+
+// GetTaskResult handles the tasks/result method; it blocks until the task finishes.
+func (h *Handler) GetTaskResult(ctx context.Context, request *jsonrpc.Request) (*schema.CallToolResult, *jsonrpc.Error) {
+	params := &schema.GetTaskPayloadRequestParams{}
+	if err := json.Unmarshal(request.Params, params); err != nil {
+		return nil, jsonrpc.NewInvalidParamsError(fmt.Sprintf("failed to parse: %v", err), request.Params)
+	}
+	entry, err := h.tasks.Await(ctx, h.taskOwner(ctx), params.TaskId)
+	if err != nil {
+		return nil, taskError(err)
+	}
+	if entry.Error != nil {
+		return nil, entry.Error
+	}
+	if entry.Task.Status == schema.TaskStatusCancelled {
+		return nil, jsonrpc.NewInvalidRequest(fmt.Sprintf("task %v was cancelled", params.TaskId), nil)
+	}
+	// the stored result is shared by concurrent tasks/result calls; annotate a copy
+	result := &schema.CallToolResult{Content: []schema.CallToolResultContentElem{}}
+	if entry.Result != nil {
+		*result = *entry.Result
+	}
+	result.Meta = make(map[string]interface{}, len(result.Meta)+1)
+	if entry.Result != nil {
+		for key, value := range entry.Result.Meta {
+			result.Meta[key] = value
+		}
+	}
+	result.Meta[task.RelatedTaskMetaKey] = map[string]interface{}{"taskId": params.TaskId}
+	return result, nil
+}
+
+// CancelTask handles the tasks/cancel method.
+func (h *Handler) CancelTask(ctx context.Context, request *jsonrpc.Request) (*schema.Task, *jsonrpc.Error) {
+	params := &schema.CancelTaskRequestParams{}
+	if err := json.Unmarshal(request.Params, params); err != nil {
+		return nil, jsonrpc.NewInvalidParamsError(fmt.Sprintf("failed to parse: %v", err), request.Params)
+	}
+	aTask, err := h.tasks.Cancel(ctx, h.taskOwner(ctx), params.TaskId)
+	if err != nil {
+		return nil, taskError(err)
+	}
+	h.notifyTaskStatus(ctx, aTask)
+	return aTask, nil
+}
+
+// ListTasks handles the tasks/list method.
+func (h *Handler) ListTasks(ctx context.Context, request *jsonrpc.Request) (*schema.ListTasksResult, *jsonrpc.Error) {
+	tasks, err := h.tasks.List(ctx, h.taskOwner(ctx))
+	if err != nil {
+		return nil, jsonrpc.NewInternalError(err.Error(), nil)
+	}
+	return &schema.ListTasksResult{Tasks: tasks}, nil
+}
+
+func taskError(err error) *jsonrpc.Error {
+	if errors.Is(err, task.ErrNotFound) {
+		return jsonrpc.NewInvalidParamsError(err.Error(), nil)
+	}
+	return jsonrpc.NewInvalidRequest(err.Error(), nil)
+}
+
+// taskCapabilities advertises task support.
+func taskCapabilities() *schema.ServerCapabilitiesTasks {
+	return &schema.ServerCapabilitiesTasks{
+		Cancel:   map[string]interface{}{},
+		List:     map[string]interface{}{},
+		Requests: &schema.ServerCapabilitiesTasksRequests{Tools: &schema.ServerCapabilitiesTasksRequestsTools{Call: map[string]interface{}{}}},
+	}
+}
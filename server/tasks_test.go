@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+	"github.com/viant/mcp/client"
+	"github.com/viant/mcp/server/task"
+)
+
+func TestServer_Tasks(t *testing.T) {
+	release := make(chan struct{})
+	newHandler := serverproto.WithDefaultHandler(context.Background(), func(server *serverproto.DefaultHandler) error {
+		server.RegisterToolWithSchema("echo", "echoes text", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				text, _ := request.Params.Arguments["text"].(string)
+				return &schema.CallToolResult{Content: []schema.CallToolResultContentElem{schema.TextContent{Type: "text", Text: text}}}, nil
+			})
+		server.RegisterToolWithSchema("meta", "returns metadata", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				return &schema.CallToolResult{Meta: map[string]interface{}{"source": "meta"}, Content: []schema.CallToolResultContentElem{}}, nil
+			})
+		server.RegisterToolWithSchema("block", "waits until released or cancelled", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				select {
+				case <-release:
+				case <-ctx.Done():
+				}
+				return &schema.CallToolResult{Content: []schema.CallToolResultContentElem{schema.TextContent{Type: "text", Text: "released"}}}, nil
+			})
+		server.RegisterToolWithSchema("report", "detaches into a task", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				return task.Async(ctx, func(ctx context.Context) (*schema.CallToolResult, *jsonrpc.Error) {
+					task.ReportStatus(ctx, "building")
+					return &schema.CallToolResult{Content: []schema.CallToolResultContentElem{schema.TextContent{Type: "text", Text: "report ready"}}}, nil
+				})
+			})
+		return nil
+	})
+	srv, err := New(WithNewHandler(newHandler), WithTasks(task.New(task.WithPollInterval(10*time.Millisecond))))
+	if !assert.NoError(t, err) {
+		return
+	}
+	ctx := context.Background()
+
+	testCases := []struct {
+		name         string
+		tool         string
+		cancel       bool
+		expectStatus schema.TaskStatus
+		expectText   string
+	}{
+		{name: "task augmented call", tool: "echo", expectStatus: schema.TaskStatusCompleted, expectText: "hi"},
+		{name: "cancelled task", tool: "block", cancel: true, expectStatus: schema.TaskStatusCancelled},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			aClient := srv.InProcessClient(ctx, nil)
+			initResult, err := aClient.Initialize(ctx)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.NotNil(t, initResult.Capabilities.Tasks)
+			created, err := aClient.CallToolAsTask(ctx, &schema.CallToolRequestParams{Name: testCase.tool, Arguments: map[string]interface{}{"text": "hi"}}, nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+			taskID := created.Task.TaskId
+			assert.NotEmpty(t, taskID)
+			if testCase.cancel {
+				cancelled, err := aClient.CancelTask(ctx, taskID)
+				if !assert.NoError(t, err) {
+					return
+				}
+				assert.Equal(t, schema.TaskStatusCancelled, cancelled.Status)
+				_, err = aClient.GetTaskResult(ctx, taskID)
+				assert.Error(t, err)
+			} else {
+				result, err := aClient.AwaitTask(ctx, taskID, 5*time.Millisecond)
+				if !assert.NoError(t, err) {
+					return
+				}
+				data, _ := json.Marshal(result.Content)
+				assert.Contains(t, string(data), testCase.expectText)
+				assert.Equal(t, taskID, client.RelatedTaskID(result))
+			}
+			aTask, err := aClient.GetTask(ctx, taskID)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, testCase.expectStatus, aTask.Status)
+			listed, err := aClient.ListTasks(ctx, nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Len(t, listed.Tasks, 1)
+		})
+	}
+
+	t.Run("tool detaches with Async", func(t *testing.T) {
+		aClient := srv.InProcessClient(ctx, nil)
+		result, err := aClient.CallTool(ctx, &schema.CallToolRequestParams{Name: "report"})
+		if !assert.NoError(t, err) {
+			return
+		}
+		taskID := client.RelatedTaskID(result)
+		if !assert.NotEmpty(t, taskID) {
+			return
+		}
+		result, err = aClient.GetTaskResult(ctx, taskID)
+		if !assert.NoError(t, err) {
+			return
+		}
+		data, _ := json.Marshal(result.Content)
+		assert.Contains(t, string(data), "report ready")
+		aTask, err := aClient.GetTask(ctx, taskID)
+		if assert.NoError(t, err) && assert.NotNil(t, aTask.StatusMessage) {
+			assert.Equal(t, "building", *aTask.StatusMessage)
+		}
+	})
+
+	t.Run("concurrent results", func(t *testing.T) {
+		aClient := srv.InProcessClient(ctx, nil)
+		created, err := aClient.CallToolAsTask(ctx, &schema.CallToolRequestParams{Name: "meta"}, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		taskID := created.Task.TaskId
+		var waitGroup sync.WaitGroup
+		results := make([]*schema.CallToolResult, 8)
+		for i := range results {
+			waitGroup.Add(1)
+			go func(i int) {
+				defer waitGroup.Done()
+				results[i], _ = aClient.GetTaskResult(ctx, taskID)
+			}(i)
+		}
+		waitGroup.Wait()
+		for _, result := range results {
+			if assert.NotNil(t, result) {
+				assert.Equal(t, "meta", result.Meta["source"])
+				assert.Equal(t, taskID, client.RelatedTaskID(result))
+			}
+		}
+	})
+
+	t.Run("tasks of another session are not visible", func(t *testing.T) {
+		owner := srv.InProcessClient(ctx, nil)
+		created, err := owner.CallToolAsTask(ctx, &schema.CallToolRequestParams{Name: "echo"}, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		other := srv.InProcessClient(ctx, nil)
+		_, err = other.GetTask(ctx, created.Task.TaskId)
+		assert.Error(t, err)
+	})
+	close(release)
+}
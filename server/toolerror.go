@@ -0,0 +1,164 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+// ToolError is a tool execution error carrying a JSON-RPC code and a user-facing message.
+// Tools report it with Fail; by default it is returned as a CallToolResult with isError set,
+// regardless of its code.
+type ToolError struct {
+	// Code is the error code reported in the structured payload; defaults to jsonrpc.InternalError.
+	Code int
+	// UserMessage is shown to the user or model; Err message is used when empty.
+	UserMessage string
+	// Data is optional additional error information.
+	Data interface{}
+	// Err is the underlying cause.
+	Err error
+}
+
+// Error returns the error message.
+func (e *ToolError) Error() string {
+	switch {
+	case e.Err != nil && e.UserMessage != "":
+		return e.UserMessage + ": " + e.Err.Error()
+	case e.Err != nil:
+		return e.Err.Error()
+	}
+	return e.UserMessage
+}
+
+// Unwrap returns the underlying cause.
+func (e *ToolError) Unwrap() error {
+	return e.Err
+}
+
+// Message returns the user-facing message.
+func (e *ToolError) Message() string {
+	if e.UserMessage != "" {
+		return e.UserMessage
+	}
+	return e.Error()
+}
+
+// ToolErrorMapper maps a failed tools/call to a tool result (execution error) or a JSON-RPC error (protocol error).
+// cause is the Go error reported with Fail, or nil.
+type ToolErrorMapper func(ctx context.Context, tool string, rpcError *jsonrpc.Error, cause error) (*schema.CallToolResult, *jsonrpc.Error)
+
+type causeKey string
+
+const toolErrorCauseKey causeKey = "tool-error-cause"
+
+// toolErrorCause holds the Go error reported by the running tool.
+type toolErrorCause struct {
+	err error
+}
+
+const toolOutcomeKey causeKey = "tool-outcome"
+
+// toolOutcome records the error the tool error mapper converted into the result, for REST status mapping.
+type toolOutcome struct {
+	mapped *jsonrpc.Error
+}
+
+// Fail converts err into a JSON-RPC error for a tool handler to return; the original error stays
+// available to the ToolErrorMapper. A *ToolError (found with errors.As) provides the code and message.
+func Fail(ctx context.Context, err error) *jsonrpc.Error {
+	if err == nil {
+		return nil
+	}
+	if holder, _ := ctx.Value(toolErrorCauseKey).(*toolErrorCause); holder != nil {
+		holder.err = err
+	}
+	var toolError *ToolError
+	if !errors.As(err, &toolError) {
+		return jsonrpc.NewInternalError(err.Error(), nil)
+	}
+	code := toolError.Code
+	if code == 0 {
+		code = jsonrpc.InternalError
+	}
+	return jsonrpc.NewError(code, toolError.Message(), toolError.Data)
+}
+
+// protocolError marks a tools/call failure reported by the dispatcher rather than by the tool.
+type protocolError struct {
+	rpcError *jsonrpc.Error
+}
+
+// Error returns the error message.
+func (e *protocolError) Error() string {
+	return e.rpcError.Message
+}
+
+// ProtocolError marks rpcError as a protocol error (unknown tool, invalid arguments) of the running tools/call
+// and returns it; handlers dispatching tool calls themselves use it to keep the error a JSON-RPC error.
+func ProtocolError(ctx context.Context, rpcError *jsonrpc.Error) *jsonrpc.Error {
+	if holder, _ := ctx.Value(toolErrorCauseKey).(*toolErrorCause); holder != nil {
+		holder.err = &protocolError{rpcError: rpcError}
+	}
+	return rpcError
+}
+
+// IsProtocolError returns true when cause was reported with ProtocolError.
+func IsProtocolError(cause error) bool {
+	var marked *protocolError
+	return errors.As(cause, &marked)
+}
+
+// DefaultToolErrorMapper keeps errors marked with ProtocolError as JSON-RPC errors and converts everything else,
+// including any *ToolError and errors returned by the tool itself, into a CallToolResult with isError set.
+func DefaultToolErrorMapper(ctx context.Context, tool string, rpcError *jsonrpc.Error, cause error) (*schema.CallToolResult, *jsonrpc.Error) {
+	if IsProtocolError(cause) {
+		return nil, rpcError
+	}
+	return NewToolErrorResult(rpcError), nil
+}
+
+// NewToolErrorResult returns a CallToolResult with isError set and a structured {error, code, message, data} payload.
+func NewToolErrorResult(rpcError *jsonrpc.Error) *schema.CallToolResult {
+	isError := true
+	structured := map[string]interface{}{
+		"error":   true,
+		"code":    rpcError.Code,
+		"message": rpcError.Message,
+	}
+	if len(rpcError.Data) > 0 {
+		structured["data"] = json.RawMessage(rpcError.Data)
+	}
+	return &schema.CallToolResult{
+		IsError:           &isError,
+		StructuredContent: structured,
+		Content: []schema.CallToolResultContentElem{
+			schema.TextContent{Text: rpcError.Message, Type: "text"},
+		},
+	}
+}
+
+// mapToolError applies the configured mapper to a failed tools/call.
+func (h *Handler) mapToolError(ctx context.Context, request *jsonrpc.Request, rpcError *jsonrpc.Error, cause error) (*schema.CallToolResult, *jsonrpc.Error) {
+	mapper := h.toolErrorMapper
+	if mapper == nil {
+		mapper = DefaultToolErrorMapper
+	}
+	result, err := mapper(ctx, toolName(request), rpcError, cause)
+	if outcome, _ := ctx.Value(toolOutcomeKey).(*toolOutcome); outcome != nil && result != nil {
+		outcome.mapped = rpcError
+	}
+	return result, err
+}
+
+// toolName returns the tool name of a tools/call request.
+func toolName(request *jsonrpc.Request) string {
+	params := &struct {
+		Name string `json:"name"`
+	}{}
+	_ = json.Unmarshal(request.Params, params)
+	return params.Name
+}
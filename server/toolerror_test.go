@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+)
+
+func TestServer_ToolErrorMapper(t *testing.T) {
+	newHandler := serverproto.WithDefaultHandler(context.Background(), func(server *serverproto.DefaultHandler) error {
+		server.RegisterToolWithSchema("invalid", "rejects arguments", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				return nil, jsonrpc.NewInvalidParamsError("x is required", nil)
+			})
+		server.RegisterToolWithSchema("strict", "validates arguments at dispatch", schema.ToolInputSchema{Type: "object",
+			Properties: schema.ToolInputSchemaProperties{"x": {"type": "integer"}}, Required: []string{"x"}}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				return &schema.CallToolResult{}, nil
+			})
+		server.RegisterToolWithSchema("broken", "fails internally", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				return nil, Fail(ctx, errors.New("disk full"))
+			})
+		server.RegisterToolWithSchema("typed", "fails with a typed error", schema.ToolInputSchema{Type: "object"}, nil,
+			func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+				return nil, Fail(ctx, &ToolError{Code: jsonrpc.InvalidParams, UserMessage: "date must be in the future", Data: map[string]string{"field": "date"}, Err: errors.New("past date")})
+			})
+		return nil
+	})
+	customMapper := func(ctx context.Context, tool string, rpcError *jsonrpc.Error, cause error) (*schema.CallToolResult, *jsonrpc.Error) {
+		result, err := DefaultToolErrorMapper(ctx, tool, rpcError, cause)
+		if result != nil {
+			result.StructuredContent = map[string]interface{}{"tool": tool, "reason": rpcError.Message}
+		}
+		return result, err
+	}
+
+	testCases := []struct {
+		name             string
+		mapper           ToolErrorMapper
+		validate         bool
+		tool             string
+		arguments        map[string]interface{}
+		expectCode       int
+		expectIsError    bool
+		expectStructured map[string]interface{}
+	}{
+		{name: "unknown tool is a protocol error", validate: true, tool: "missing", expectCode: jsonrpc.MethodNotFound},
+		{name: "missing argument is a protocol error", validate: true, tool: "strict", expectCode: jsonrpc.InvalidParams},
+		{name: "mistyped argument is a protocol error", validate: true, tool: "strict", arguments: map[string]interface{}{"x": "one"}, expectCode: jsonrpc.InvalidParams},
+		{name: "valid arguments", validate: true, tool: "strict", arguments: map[string]interface{}{"x": 1}},
+		{name: "arguments not validated by default", tool: "strict"},
+		{name: "unknown tool from the handler becomes a result", tool: "missing", expectIsError: true,
+			expectStructured: map[string]interface{}{"error": true, "code": float64(jsonrpc.MethodNotFound), "message": "tool missing not found"}},
+		{name: "invalid params from the tool becomes a result", tool: "invalid", expectIsError: true,
+			expectStructured: map[string]interface{}{"error": true, "code": float64(jsonrpc.InvalidParams), "message": "x is required"}},
+		{name: "execution error becomes a result", tool: "broken", expectIsError: true,
+			expectStructured: map[string]interface{}{"error": true, "code": float64(jsonrpc.InternalError), "message": "disk full"}},
+		{name: "typed error becomes a result", tool: "typed", expectIsError: true,
+			expectStructured: map[string]interface{}{"error": true, "code": float64(jsonrpc.InvalidParams), "message": "date must be in the future", "data": map[string]interface{}{"field": "date"}}},
+		{name: "custom payload", mapper: customMapper, tool: "broken", expectIsError: true,
+			expectStructured: map[string]interface{}{"tool": "broken", "reason": "disk full"}},
+	}
+	ctx := context.Background()
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			options := []Option{WithNewHandler(newHandler)}
+			if testCase.mapper != nil {
+				options = append(options, WithToolErrorMapper(testCase.mapper))
+			}
+			if testCase.validate {
+				options = append(options, WithToolValidation())
+			}
+			srv, err := New(options...)
+			if !assert.NoError(t, err) {
+				return
+			}
+			result, err := srv.InProcessClient(ctx, nil).CallTool(ctx, &schema.CallToolRequestParams{Name: testCase.tool, Arguments: testCase.arguments})
+			if testCase.expectCode != 0 {
+				rpcError := &jsonrpc.Error{}
+				if assert.ErrorAs(t, err, &rpcError) {
+					assert.Equal(t, testCase.expectCode, rpcError.Code)
+				}
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, testCase.expectIsError, result.IsError != nil && *result.IsError)
+			assert.EqualValues(t, testCase.expectStructured, result.StructuredContent)
+		})
+	}
+}
@@ -18,12 +18,81 @@ func (h *Handler) ListTools(ctx context.Context, request *jsonrpc.Request) (*sch
 	return h.handler.ListTools(ctx, &jsonrpc.TypedRequest[*schema.ListToolsRequest]{Request: listToolsRequest, Id: uint64(id)})
 }
 
-// CallTool handles the tools/call method
+// CallTool handles the tools/call method; malformed params are reported with ProtocolError, as are unknown tools
+// and arguments violating the tool input schema when WithToolValidation is set.
 func (h *Handler) CallTool(ctx context.Context, request *jsonrpc.Request) (*schema.CallToolResult, *jsonrpc.Error) {
 	callToolRequest := &schema.CallToolRequest{Method: request.Method}
 	if err := json.Unmarshal(request.Params, &callToolRequest.Params); err != nil {
-		return nil, jsonrpc.NewInvalidParamsError(fmt.Sprintf("failed to parse: %v", err), request.Params)
+		return nil, ProtocolError(ctx, jsonrpc.NewInvalidParamsError(fmt.Sprintf("failed to parse: %v", err), request.Params))
+	}
+	if registry, ok := h.handler.(toolRegistry); ok && h.toolValidation {
+		tool := lookupTool(registry, callToolRequest.Params.Name)
+		if tool == nil {
+			return nil, ProtocolError(ctx, jsonrpc.NewMethodNotFound(fmt.Sprintf("tool %v not found", callToolRequest.Params.Name), nil))
+		}
+		if err := validateArguments(&tool.InputSchema, callToolRequest.Params.Arguments); err != nil {
+			return nil, ProtocolError(ctx, jsonrpc.NewInvalidParamsError(err.Error(), nil))
+		}
 	}
 	id, _ := jsonrpc.AsRequestIntId(request.Id)
 	return h.handler.CallTool(ctx, &jsonrpc.TypedRequest[*schema.CallToolRequest]{Request: callToolRequest, Id: uint64(id)})
 }
+
+// toolRegistry is implemented by handlers embedding the protocol DefaultHandler.
+type toolRegistry interface {
+	ListRegisteredTools() []schema.Tool
+}
+
+// lookupTool returns the registered tool with name, or nil.
+func lookupTool(registry toolRegistry, name string) *schema.Tool {
+	for _, tool := range registry.ListRegisteredTools() {
+		if tool.Name == name {
+			return &tool
+		}
+	}
+	return nil
+}
+
+// validateArguments checks required arguments and the JSON types of top-level arguments.
+func validateArguments(inputSchema *schema.ToolInputSchema, arguments map[string]interface{}) error {
+	for _, name := range inputSchema.Required {
+		if _, ok := arguments[name]; !ok {
+			return fmt.Errorf("%v is required", name)
+		}
+	}
+	for name, value := range arguments {
+		property, ok := inputSchema.Properties[name]
+		if !ok || value == nil {
+			continue
+		}
+		if expected, _ := property["type"].(string); expected != "" && !hasJSONType(value, expected) {
+			return fmt.Errorf("%v must be of type %v", name, expected)
+		}
+	}
+	return nil
+}
+
+// hasJSONType returns true when a decoded JSON value matches a JSON schema type.
+func hasJSONType(value interface{}, expected string) bool {
+	switch expected {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == float64(int64(number))
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	}
+	return true
+}
@@ -0,0 +1,13 @@
+// Package transcript records JSON-RPC traffic of MCP server sessions and
+// replays it for regression testing.
+//
+// A Recorder is attached with server.WithRecorder; it wraps every session
+// handler and transport so that client requests, server responses,
+// notifications and server-to-client back-calls (elicitation, sampling,
+// roots) are appended to a JSON lines transcript.
+//
+// A Replayer reads a transcript, drives a fresh handler created by a
+// transport.NewHandler (for example server.Server.NewHandler), answers
+// back-calls from the recorded client responses and reports differences
+// between recorded and actual results.
+package transcript
@@ -0,0 +1,81 @@
+package transcript
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/viant/jsonrpc"
+)
+
+// Direction describes which peer sent a message.
+type Direction string
+
+const (
+	// DirectionInbound marks messages sent by the client to the server.
+	DirectionInbound Direction = "in"
+	// DirectionOutbound marks messages sent by the server to the client.
+	DirectionOutbound Direction = "out"
+)
+
+// Entry represents a single recorded JSON-RPC message.
+type Entry struct {
+	Seq       uint64              `json:"seq"`
+	Time      time.Time           `json:"time"`
+	Session   string              `json:"session"`
+	Direction Direction           `json:"direction"`
+	Type      jsonrpc.MessageType `json:"type"`
+	Method    string              `json:"method,omitempty"`
+	Message   json.RawMessage     `json:"message"`
+}
+
+// Request decodes a request entry.
+func (e *Entry) Request() (*jsonrpc.Request, error) {
+	ret := &jsonrpc.Request{}
+	return ret, json.Unmarshal(e.Message, ret)
+}
+
+// Response decodes a response entry.
+func (e *Entry) Response() (*jsonrpc.Response, error) {
+	ret := &jsonrpc.Response{}
+	return ret, json.Unmarshal(e.Message, ret)
+}
+
+// Notification decodes a notification entry.
+func (e *Entry) Notification() (*jsonrpc.Notification, error) {
+	ret := &jsonrpc.Notification{}
+	return ret, json.Unmarshal(e.Message, ret)
+}
+
+// Load reads transcript entries from JSON lines.
+func Load(reader io.Reader) ([]*Entry, error) {
+	var ret []*Entry
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		entry := &Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			return nil, fmt.Errorf("invalid transcript entry at line %d: %w", line, err)
+		}
+		ret = append(ret, entry)
+	}
+	return ret, scanner.Err()
+}
+
+// LoadFile reads transcript entries from a file.
+func LoadFile(path string) ([]*Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Load(file)
+}
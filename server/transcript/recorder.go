@@ -0,0 +1,235 @@
+package transcript
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/jsonrpc/transport"
+)
+
+// Recorder appends JSON-RPC traffic of all sessions to a transcript writer.
+type Recorder struct {
+	mu       sync.Mutex
+	writer   io.Writer
+	closer   io.Closer
+	redactor Redactor
+	seq      uint64
+	err      error
+}
+
+// Option customizes Recorder.
+type Option func(r *Recorder)
+
+// WithRedactor sets the redactor applied to every message before it is written (default DefaultRedactor);
+// nil records messages verbatim.
+func WithRedactor(redactor Redactor) Option {
+	return func(r *Recorder) {
+		r.redactor = redactor
+	}
+}
+
+// NewRecorder creates a recorder writing JSON lines to writer.
+func NewRecorder(writer io.Writer, options ...Option) *Recorder {
+	ret := &Recorder{writer: writer, redactor: DefaultRedactor()}
+	if closer, ok := writer.(io.Closer); ok {
+		ret.closer = closer
+	}
+	for _, option := range options {
+		option(ret)
+	}
+	return ret
+}
+
+// NewFileRecorder creates a recorder appending to the transcript file at path.
+func NewFileRecorder(path string, options ...Option) (*Recorder, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return NewRecorder(file, options...), nil
+}
+
+// Err returns the first write error encountered by the recorder.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Close closes the underlying writer when it is closable.
+func (r *Recorder) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+// Session starts recording a new session.
+func (r *Recorder) Session() *Session {
+	return &Session{ID: uuid.NewString(), recorder: r}
+}
+
+// Wrap returns a transport.NewHandler that records every session created by newHandler.
+func (r *Recorder) Wrap(newHandler transport.NewHandler) transport.NewHandler {
+	return func(ctx context.Context, aTransport transport.Transport) transport.Handler {
+		session := r.Session()
+		wrapped := session.Transport(aTransport)
+		return session.Handler(newHandler(ctx, wrapped))
+	}
+}
+
+func (r *Recorder) write(session string, direction Direction, messageType jsonrpc.MessageType, method string, message interface{}) {
+	data, err := json.Marshal(message)
+	if err == nil && r.redactor != nil {
+		data, err = redact(r.redactor, data)
+	}
+	if err != nil {
+		r.setErr(err)
+		return
+	}
+	entry := &Entry{
+		Seq:       atomic.AddUint64(&r.seq, 1),
+		Time:      time.Now().UTC(),
+		Session:   session,
+		Direction: direction,
+		Type:      messageType,
+		Method:    method,
+		Message:   data,
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		r.setErr(err)
+		return
+	}
+	line = append(line, '\n')
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err = r.writer.Write(line); err != nil && r.err == nil {
+		r.err = err
+	}
+}
+
+// redact applies redactor to a JSON object message.
+func redact(redactor Redactor, data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var message map[string]interface{}
+	if err := decoder.Decode(&message); err != nil {
+		return nil, err
+	}
+	return json.Marshal(redactor.Redact(message))
+}
+
+func (r *Recorder) setErr(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+// Session records messages of a single client session.
+type Session struct {
+	ID       string
+	recorder *Recorder
+}
+
+// Handler wraps a session handler to record client requests, server responses and client notifications.
+func (s *Session) Handler(handler transport.Handler) transport.Handler {
+	return &recordingHandler{session: s, handler: handler}
+}
+
+// Transport wraps a session transport to record server-to-client requests, client responses and server notifications.
+func (s *Session) Transport(aTransport transport.Transport) transport.Transport {
+	ret := &recordingTransport{session: s, transport: aTransport}
+	ret.sequencer, _ = aTransport.(transport.Sequencer)
+	return ret
+}
+
+type recordingHandler struct {
+	session *Session
+	handler transport.Handler
+}
+
+// Serve records the request and the produced response.
+func (h *recordingHandler) Serve(ctx context.Context, request *jsonrpc.Request, response *jsonrpc.Response) {
+	recorder := h.session.recorder
+	recorder.write(h.session.ID, DirectionInbound, jsonrpc.MessageTypeRequest, request.Method, request)
+	h.handler.Serve(ctx, request, response)
+	if response.Id == nil {
+		response.Id = request.Id
+	}
+	if response.Jsonrpc == "" {
+		response.Jsonrpc = request.Jsonrpc
+	}
+	recorder.write(h.session.ID, DirectionOutbound, jsonrpc.MessageTypeResponse, request.Method, response)
+}
+
+// OnNotification records the notification before passing it on.
+func (h *recordingHandler) OnNotification(ctx context.Context, notification *jsonrpc.Notification) {
+	h.session.recorder.write(h.session.ID, DirectionInbound, jsonrpc.MessageTypeNotification, notification.Method, notification)
+	h.handler.OnNotification(ctx, notification)
+}
+
+type recordingTransport struct {
+	session   *Session
+	transport transport.Transport
+	sequencer transport.Sequencer
+	seq       uint64
+}
+
+// Send records a server-to-client request and the client response.
+func (t *recordingTransport) Send(ctx context.Context, request *jsonrpc.Request) (*jsonrpc.Response, error) {
+	recorder := t.session.recorder
+	recorder.write(t.session.ID, DirectionOutbound, jsonrpc.MessageTypeRequest, request.Method, request)
+	if t.transport == nil {
+		return nil, errors.New("transport is not available")
+	}
+	response, err := t.transport.Send(ctx, request)
+	if err != nil {
+		recorder.write(t.session.ID, DirectionInbound, jsonrpc.MessageTypeResponse, request.Method, &jsonrpc.Response{
+			Id: request.Id, Jsonrpc: request.Jsonrpc, Error: jsonrpc.NewInternalError(err.Error(), nil),
+		})
+		return nil, err
+	}
+	recorder.write(t.session.ID, DirectionInbound, jsonrpc.MessageTypeResponse, request.Method, response)
+	return response, nil
+}
+
+// Notify records a server-to-client notification.
+func (t *recordingTransport) Notify(ctx context.Context, notification *jsonrpc.Notification) error {
+	t.session.recorder.write(t.session.ID, DirectionOutbound, jsonrpc.MessageTypeNotification, notification.Method, notification)
+	if t.transport == nil {
+		return nil
+	}
+	return t.transport.Notify(ctx, notification)
+}
+
+// NextRequestID delegates to the underlying sequencer when available.
+func (t *recordingTransport) NextRequestID() jsonrpc.RequestId {
+	if t.sequencer != nil {
+		return t.sequencer.NextRequestID()
+	}
+	return int(atomic.AddUint64(&t.seq, 1))
+}
+
+// LastRequestID delegates to the underlying sequencer when available.
+func (t *recordingTransport) LastRequestID() jsonrpc.RequestId {
+	if t.sequencer != nil {
+		return t.sequencer.LastRequestID()
+	}
+	return int(atomic.LoadUint64(&t.seq))
+}
@@ -0,0 +1,35 @@
+package transcript
+
+import "github.com/viant/mcp/server/audit"
+
+// Redactor removes secrets from a decoded JSON-RPC message before it is written to a transcript.
+// *audit.Redactor implements it.
+type Redactor interface {
+	Redact(message map[string]interface{}) map[string]interface{}
+}
+
+// DefaultRules returns rules masking authorization metadata, tokens, cookies and common secret fields.
+// Unlike audit.DefaultRules they do not match protocol fields such as progressToken or maxTokens.
+func DefaultRules() []audit.Rule {
+	return []audit.Rule{
+		{Pattern: "authorization"},
+		{Pattern: "token"},
+		{Pattern: "*_token"},
+		{Pattern: "accesstoken"},
+		{Pattern: "refreshtoken"},
+		{Pattern: "idtoken"},
+		{Pattern: "cookie"},
+		{Pattern: "*password*"},
+		{Pattern: "*passwd*"},
+		{Pattern: "*secret*"},
+		{Pattern: "*apikey*"},
+		{Pattern: "*api_key*"},
+		{Pattern: "*credential*"},
+		{Pattern: "*private_key*"},
+	}
+}
+
+// DefaultRedactor returns the redactor used by recorders and replayers unless overridden.
+func DefaultRedactor() Redactor {
+	return audit.NewRedactor(DefaultRules()...)
+}
@@ -0,0 +1,247 @@
+package transcript
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/viant/jsonrpc"
+	"github.com/viant/jsonrpc/transport"
+)
+
+// Replayer re-runs recorded client traffic against a fresh handler and compares responses.
+type Replayer struct {
+	newHandler transport.NewHandler
+	ignored    [][]string
+	redactor   Redactor
+}
+
+// ReplayOption customizes Replayer.
+type ReplayOption func(r *Replayer)
+
+// WithIgnoredFields excludes dotted result paths (e.g. "serverInfo.version") from comparison.
+func WithIgnoredFields(paths ...string) ReplayOption {
+	return func(r *Replayer) {
+		for _, aPath := range paths {
+			r.ignored = append(r.ignored, strings.Split(aPath, "."))
+		}
+	}
+}
+
+// WithReplayRedactor sets the redactor applied to actual results before comparison; it should match the
+// recorder redactor (default DefaultRedactor), nil compares actual results verbatim.
+func WithReplayRedactor(redactor Redactor) ReplayOption {
+	return func(r *Replayer) {
+		r.redactor = redactor
+	}
+}
+
+// NewReplayer creates a replayer driving handlers created by newHandler.
+func NewReplayer(newHandler transport.NewHandler, options ...ReplayOption) *Replayer {
+	ret := &Replayer{newHandler: newHandler, redactor: DefaultRedactor()}
+	for _, option := range options {
+		option(ret)
+	}
+	return ret
+}
+
+// ReplayFile replays the transcript stored at path.
+func (r *Replayer) ReplayFile(ctx context.Context, path string) (*Report, error) {
+	entries, err := LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return r.Replay(ctx, entries)
+}
+
+// Replay replays entries session by session, in recorded order.
+func (r *Replayer) Replay(ctx context.Context, entries []*Entry) (*Report, error) {
+	report := &Report{}
+	var sessions []string
+	bySession := map[string][]*Entry{}
+	for _, entry := range entries {
+		if _, ok := bySession[entry.Session]; !ok {
+			sessions = append(sessions, entry.Session)
+		}
+		bySession[entry.Session] = append(bySession[entry.Session], entry)
+	}
+	for _, session := range sessions {
+		report.Sessions++
+		if err := r.replaySession(ctx, session, bySession[session], report); err != nil {
+			return report, fmt.Errorf("failed to replay session %v: %w", session, err)
+		}
+	}
+	return report, nil
+}
+
+func (r *Replayer) replaySession(ctx context.Context, session string, entries []*Entry, report *Report) error {
+	stub := &replayTransport{backCalls: map[string][]*jsonrpc.Response{}}
+	expected := map[string]*Entry{}
+	for _, entry := range entries {
+		if entry.Type != jsonrpc.MessageTypeResponse {
+			continue
+		}
+		switch entry.Direction {
+		case DirectionInbound:
+			response, err := entry.Response()
+			if err != nil {
+				return err
+			}
+			stub.backCalls[entry.Method] = append(stub.backCalls[entry.Method], response)
+		case DirectionOutbound:
+			response, err := entry.Response()
+			if err != nil {
+				return err
+			}
+			expected[requestKey(response.Id)] = entry
+		}
+	}
+
+	handler := r.newHandler(ctx, stub)
+	for _, entry := range entries {
+		if entry.Direction != DirectionInbound {
+			continue
+		}
+		switch entry.Type {
+		case jsonrpc.MessageTypeNotification:
+			notification, err := entry.Notification()
+			if err != nil {
+				return err
+			}
+			handler.OnNotification(ctx, notification)
+		case jsonrpc.MessageTypeRequest:
+			request, err := entry.Request()
+			if err != nil {
+				return err
+			}
+			report.Requests++
+			response := &jsonrpc.Response{Id: request.Id, Jsonrpc: request.Jsonrpc}
+			handler.Serve(ctx, request, response)
+			recorded, ok := expected[requestKey(request.Id)]
+			if !ok {
+				continue
+			}
+			if diff := r.compare(recorded, response); diff != nil {
+				diff.Session = session
+				diff.Seq = entry.Seq
+				diff.Method = request.Method
+				diff.Id = request.Id
+				report.Diffs = append(report.Diffs, diff)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Replayer) compare(recorded *Entry, actual *jsonrpc.Response) *Diff {
+	expected, err := recorded.Response()
+	if err != nil {
+		return &Diff{Reason: err.Error()}
+	}
+	if !sameError(expected.Error, actual.Error) {
+		return &Diff{ExpectedError: expected.Error, ActualError: actual.Error, Reason: "error mismatch"}
+	}
+	expectedValue, err := r.normalize(expected.Result)
+	if err != nil {
+		return &Diff{Reason: fmt.Sprintf("invalid recorded result: %v", err)}
+	}
+	actualResult := actual.Result
+	if r.redactor != nil && len(actualResult) > 0 {
+		if redacted, err := redact(r.redactor, actualResult); err == nil {
+			actualResult = redacted
+		}
+	}
+	actualValue, err := r.normalize(actualResult)
+	if err != nil {
+		return &Diff{Reason: fmt.Sprintf("invalid actual result: %v", err)}
+	}
+	if reflect.DeepEqual(expectedValue, actualValue) {
+		return nil
+	}
+	return &Diff{Expected: expected.Result, Actual: actual.Result, Reason: "result mismatch"}
+}
+
+func (r *Replayer) normalize(data json.RawMessage) (interface{}, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var ret interface{}
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, err
+	}
+	for _, aPath := range r.ignored {
+		removePath(ret, aPath)
+	}
+	return ret, nil
+}
+
+func removePath(value interface{}, aPath []string) {
+	aMap, ok := value.(map[string]interface{})
+	if !ok || len(aPath) == 0 {
+		return
+	}
+	if len(aPath) == 1 {
+		delete(aMap, aPath[0])
+		return
+	}
+	switch child := aMap[aPath[0]].(type) {
+	case map[string]interface{}:
+		removePath(child, aPath[1:])
+	case []interface{}:
+		for _, item := range child {
+			removePath(item, aPath[1:])
+		}
+	}
+}
+
+func sameError(expected, actual *jsonrpc.Error) bool {
+	if expected == nil || actual == nil {
+		return expected == nil && actual == nil
+	}
+	return expected.Code == actual.Code && expected.Message == actual.Message
+}
+
+func requestKey(id jsonrpc.RequestId) string {
+	data, _ := json.Marshal(id)
+	return string(data)
+}
+
+// replayTransport answers server-to-client requests with recorded client responses.
+type replayTransport struct {
+	mu        sync.Mutex
+	backCalls map[string][]*jsonrpc.Response
+	seq       uint64
+}
+
+// Send returns the next recorded response for the request method.
+func (t *replayTransport) Send(ctx context.Context, request *jsonrpc.Request) (*jsonrpc.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	queue := t.backCalls[request.Method]
+	if len(queue) == 0 {
+		return nil, fmt.Errorf("no recorded response for %v", request.Method)
+	}
+	response := *queue[0]
+	t.backCalls[request.Method] = queue[1:]
+	response.Id = request.Id
+	return &response, nil
+}
+
+// Notify discards server notifications.
+func (t *replayTransport) Notify(ctx context.Context, notification *jsonrpc.Notification) error {
+	return nil
+}
+
+// NextRequestID returns the next back-call request id.
+func (t *replayTransport) NextRequestID() jsonrpc.RequestId {
+	return int(atomic.AddUint64(&t.seq, 1))
+}
+
+// LastRequestID returns the last back-call request id.
+func (t *replayTransport) LastRequestID() jsonrpc.RequestId {
+	return int(atomic.LoadUint64(&t.seq))
+}
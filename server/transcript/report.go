@@ -0,0 +1,68 @@
+package transcript
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/viant/jsonrpc"
+)
+
+// Report summarizes a replay.
+type Report struct {
+	Sessions int     `json:"sessions"`
+	Requests int     `json:"requests"`
+	Diffs    []*Diff `json:"diffs,omitempty"`
+}
+
+// HasDiffs reports whether any replayed response differs from the recorded one.
+func (r *Report) HasDiffs() bool {
+	return len(r.Diffs) > 0
+}
+
+// String returns a human readable summary.
+func (r *Report) String() string {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("sessions: %d, requests: %d, diffs: %d", r.Sessions, r.Requests, len(r.Diffs)))
+	for _, diff := range r.Diffs {
+		builder.WriteString("\n")
+		builder.WriteString(diff.String())
+	}
+	return builder.String()
+}
+
+// Diff describes a response that differs from the recorded one.
+type Diff struct {
+	Session       string            `json:"session"`
+	Seq           uint64            `json:"seq"`
+	Method        string            `json:"method"`
+	Id            jsonrpc.RequestId `json:"id,omitempty"`
+	Expected      json.RawMessage   `json:"expected,omitempty"`
+	Actual        json.RawMessage   `json:"actual,omitempty"`
+	ExpectedError *jsonrpc.Error    `json:"expectedError,omitempty"`
+	ActualError   *jsonrpc.Error    `json:"actualError,omitempty"`
+	Reason        string            `json:"reason,omitempty"`
+}
+
+// String returns a human readable description of the difference.
+func (d *Diff) String() string {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("[%s #%d] %s (id: %v)", d.Session, d.Seq, d.Method, d.Id))
+	if d.Reason != "" {
+		builder.WriteString(": " + d.Reason)
+	}
+	if d.ExpectedError != nil || d.ActualError != nil {
+		builder.WriteString(fmt.Sprintf("\n  expected error: %s\n  actual error:   %s", describeError(d.ExpectedError), describeError(d.ActualError)))
+	}
+	if len(d.Expected) > 0 || len(d.Actual) > 0 {
+		builder.WriteString(fmt.Sprintf("\n  expected: %s\n  actual:   %s", d.Expected, d.Actual))
+	}
+	return builder.String()
+}
+
+func describeError(err *jsonrpc.Error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%d %s", err.Code, err.Message)
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+
+	"github.com/viant/jsonrpc"
+	authschema "github.com/viant/mcp-protocol/authorization"
+	"github.com/viant/mcp-protocol/schema"
+)
+
+// visibleTools filters tools/list result for the principal set by the authorizer when list visibility is configured.
+func (h *Handler) visibleTools(ctx context.Context, result *schema.ListToolsResult) *schema.ListToolsResult {
+	if h.visibility == nil || result == nil {
+		return result
+	}
+	result.Tools = h.visibility.FilterTools(ctx, result.Tools)
+	return result
+}
+
+// visibleResources filters resources/list result for the principal set by the authorizer when list visibility is configured.
+func (h *Handler) visibleResources(ctx context.Context, result *schema.ListResourcesResult) *schema.ListResourcesResult {
+	if h.visibility == nil || result == nil {
+		return result
+	}
+	result.Resources = h.visibility.FilterResources(ctx, result.Resources)
+	return result
+}
+
+// visiblePrompts filters prompts/list result for the principal set by the authorizer when list visibility is configured.
+func (h *Handler) visiblePrompts(ctx context.Context, result *schema.ListPromptsResult) *schema.ListPromptsResult {
+	if h.visibility == nil || result == nil {
+		return result
+	}
+	result.Prompts = h.visibility.FilterPrompts(ctx, result.Prompts)
+	return result
+}
+
+// contextWithMetaToken falls back to _meta.authorization.token when no authorizer has set a token in context.
+func contextWithMetaToken(ctx context.Context, request *jsonrpc.Request) context.Context {
+	if ctx.Value(authschema.TokenKey) != nil {
+		return ctx
+	}
+	meta := parameterMeta(request)
+	authMeta, ok := meta["authorization"].(map[string]interface{})
+	if !ok {
+		return ctx
+	}
+	token, _ := authMeta["token"].(string)
+	if token == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, authschema.TokenKey, &authschema.Token{Token: token})
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/jsonrpc"
+	"github.com/viant/mcp-protocol/authorization"
+	"github.com/viant/mcp-protocol/schema"
+	serverproto "github.com/viant/mcp-protocol/server"
+	"github.com/viant/mcp/client"
+	"github.com/viant/mcp/server/auth"
+)
+
+func TestListVisibility(t *testing.T) {
+	policy := &authorization.Policy{
+		Tools: map[string]*authorization.Authorization{
+			"admin":  {RequiredScopes: []string{"admin"}},
+			"public": nil,
+		},
+	}
+	globalPolicy := &authorization.Policy{
+		Global: &authorization.Authorization{RequiredScopes: []string{"read"}},
+		Tools: map[string]*authorization.Authorization{
+			"admin": {RequiredScopes: []string{"admin"}},
+		},
+	}
+	secret := []byte("secret")
+	verifier := func(ctx context.Context, token string) (map[string]any, error) {
+		claims := jwt.MapClaims{}
+		_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) { return secret, nil })
+		return claims, err
+	}
+	newHandler := serverproto.WithDefaultHandler(context.Background(), func(server *serverproto.DefaultHandler) error {
+		noop := func(ctx context.Context, request *schema.CallToolRequest) (*schema.CallToolResult, *jsonrpc.Error) {
+			return &schema.CallToolResult{}, nil
+		}
+		server.RegisterToolWithSchema("public", "public tool", schema.ToolInputSchema{Type: "object"}, nil, noop)
+		server.RegisterToolWithSchema("admin", "admin tool", schema.ToolInputSchema{Type: "object"}, nil, noop)
+		return nil
+	})
+	adminToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "u1", "scope": "read admin"}).SignedString(secret)
+	assert.NoError(t, err)
+	readerToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "u2", "scope": "read"}).SignedString(secret)
+	assert.NoError(t, err)
+	forgedToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "u3", "scope": "read admin"}).SignedString([]byte("forged"))
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		policy      *authorization.Policy
+		mode        auth.VisibilityMode
+		token       string
+		noVerifier  bool
+		noAuthorize bool
+		expect      []string
+		expectTag   []string
+	}{
+		{name: "hide without token", mode: auth.VisibilityHide, expect: []string{"public"}},
+		{name: "hide with insufficient scope", mode: auth.VisibilityHide, token: readerToken, expect: []string{"public"}},
+		{name: "hide with admin scope", mode: auth.VisibilityHide, token: adminToken, expect: []string{"admin", "public"}},
+		{name: "hide with forged token", mode: auth.VisibilityHide, token: forgedToken, expect: []string{"public"}},
+		{name: "hide without verifier", mode: auth.VisibilityHide, token: adminToken, noVerifier: true, expect: []string{"public"}},
+		{name: "hide meta token without authorizer", mode: auth.VisibilityHide, token: adminToken, noAuthorize: true, expect: []string{"public"}},
+		{name: "hide global rule without token", policy: globalPolicy, mode: auth.VisibilityHide},
+		{name: "hide global rule with read scope", policy: globalPolicy, mode: auth.VisibilityHide, token: readerToken, expect: []string{"public"}},
+		{name: "mark without token", mode: auth.VisibilityMark, expect: []string{"admin", "public"}, expectTag: []string{"admin"}},
+		{name: "mark with admin scope", mode: auth.VisibilityMark, token: adminToken, expect: []string{"admin", "public"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy := policy
+			if testCase.policy != nil {
+				policy = testCase.policy
+			}
+			var visibilityOptions []auth.VisibilityOption
+			if !testCase.noVerifier {
+				visibilityOptions = append(visibilityOptions, auth.WithTokenVerifier(verifier))
+			}
+			serverOptions := []Option{WithNewHandler(newHandler), WithListVisibility(auth.NewVisibility(policy, testCase.mode, visibilityOptions...))}
+			if !testCase.noAuthorize {
+				authService, err := auth.New(&auth.Config{Policy: policy})
+				if !assert.NoError(t, err) {
+					return
+				}
+				serverOptions = append(serverOptions, WithJRPCAuthorizer(authService.EnsureAuthorized))
+			}
+			srv, err := New(serverOptions...)
+			if !assert.NoError(t, err) {
+				return
+			}
+			ctx := context.Background()
+			cli := srv.AsClient(ctx)
+			_, err = cli.Initialize(ctx)
+			if !assert.NoError(t, err) {
+				return
+			}
+			var options []client.RequestOption
+			if testCase.token != "" {
+				options = append(options, client.WithAuthToken(testCase.token))
+			}
+			result, err := cli.ListTools(ctx, nil, options...)
+			if !assert.NoError(t, err) {
+				return
+			}
+			var names, tagged []string
+			for _, tool := range result.Tools {
+				names = append(names, tool.Name)
+				if _, ok := tool.Meta[auth.AuthorizationMetaKey]; ok {
+					tagged = append(tagged, tool.Name)
+				}
+			}
+			assert.ElementsMatch(t, testCase.expect, names)
+			assert.ElementsMatch(t, testCase.expectTag, tagged)
+		})
+	}
+}